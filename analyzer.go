@@ -25,19 +25,45 @@ type Analyzer struct {
 	plugins     []Plugin
 	definitions Definitions
 	depends     []string
-	k           *koanf.Koanf
+	// 自定义类型映射. key 为类型的完整名称
+	typeMappings map[string]*spec.Schema
+	// 示例文件的 glob 表达式
+	examplesPattern string
+	// 生成生产环境的文档, 隐藏声明了 @hidden-in-production 的接口
+	production bool
+	// 注解的语法错误以及未知的注解
+	diagnostics []*Diagnostic
+	reported    map[string]struct{}
+	// 插件或者配置中声明的自定义注解
+	customAnnotations map[string]struct{}
+	k                 *koanf.Koanf
 
 	doc      *spec.T
 	packages []*packages.Package
+	// 在路由声明处注册的路由
+	siteRoutes []*siteRoute
+	// 通过 Context.AnalyzeFunc 解析过的函数
+	enteredFuncs map[*ast.FuncDecl]struct{}
+}
+
+type siteRoute struct {
+	site ast.Node
+	// 常规解析时所在的顶层函数
+	root *ast.FuncDecl
+	api  *API
 }
 
 func NewAnalyzer(k *koanf.Koanf) *Analyzer {
 	a := &Analyzer{
-		routes:      make(APIs, 0),
-		globalEnv:   NewEnvironment(nil),
-		plugins:     make([]Plugin, 0),
-		definitions: make(Definitions),
-		k:           k,
+		routes:            make(APIs, 0),
+		globalEnv:         NewEnvironment(nil),
+		plugins:           make([]Plugin, 0),
+		definitions:       make(Definitions),
+		typeMappings:      make(map[string]*spec.Schema),
+		reported:          make(map[string]struct{}),
+		customAnnotations: make(map[string]struct{}),
+		k:                 k,
+		enteredFuncs:      make(map[*ast.FuncDecl]struct{}),
 	}
 
 	components := spec.NewComponents()
@@ -59,6 +85,9 @@ func (a *Analyzer) Plugin(plugins ...Plugin) *Analyzer {
 		if err != nil {
 			panic(fmt.Sprintf("mount plugin '%s' failed. error: %s", plugin.Name(), err.Error()))
 		}
+		if p, ok := plugin.(AnnotationPlugin); ok {
+			a.Annotations(p.Annotations()...)
+		}
 	}
 
 	a.plugins = append(a.plugins, plugins...)
@@ -70,6 +99,12 @@ func (a *Analyzer) Depends(pkgNames ...string) *Analyzer {
 	return a
 }
 
+// Production 生成生产环境的文档. 声明了 @hidden-in-production 的接口不会出现在文档中
+func (a *Analyzer) Production(production bool) *Analyzer {
+	a.production = production
+	return a
+}
+
 func (a *Analyzer) Process(packagePath string) *Analyzer {
 	if len(a.plugins) <= 0 {
 		panic("must register plugin before processing")
@@ -111,6 +146,7 @@ func (a *Analyzer) Process(packagePath string) *Analyzer {
 			})
 		}
 	}
+	a.loadExamples(packagePath)
 
 	return a
 }
@@ -124,6 +160,7 @@ func (a *Analyzer) Doc() *spec.T {
 }
 
 func (a *Analyzer) analyze(ctx *Context, node ast.Node) {
+	ctx = ctx.withNode(node)
 	for _, plugin := range a.plugins {
 		plugin.Analyze(ctx, node)
 	}
@@ -209,7 +246,7 @@ func (a *Analyzer) processFile(ctx *Context, file *ast.File, pkg *packages.Packa
 	ast.Inspect(file, func(node ast.Node) bool {
 		switch node := node.(type) {
 		case *ast.FuncDecl:
-			a.funDecl(ctx.Block(), node, file, pkg)
+			a.funDecl(ctx.Block().withRoot(node), node, file, pkg)
 			return false
 		case *ast.BlockStmt:
 			a.blockStmt(ctx.Block(), node, file, pkg)
@@ -343,6 +380,10 @@ func (a *Analyzer) blockStmt(ctx *Context, node *ast.BlockStmt, file *ast.File,
 			case *ast.BlockStmt:
 				a.blockStmt(ctx.Block(), node, file, pkg)
 				return false
+			case *ast.RangeStmt:
+				if a.rangeStmt(ctx, node, file, pkg) {
+					return false
+				}
 			}
 
 			a.analyze(ctx, node)
@@ -351,6 +392,44 @@ func (a *Analyzer) blockStmt(ctx *Context, node *ast.BlockStmt, file *ast.File,
 	}
 }
 
+// rangeStmt 展开对字面量 (或使用字面量初始化的变量) 的 range 循环: 将 key/value 绑定到每个元素后分别解析循环体. 用于解析以数据形式声明的路由表. e.g.
+//
+//	routes := []Route{{Method: "GET", Path: "/x", Handler: h.X}}
+//	for _, r := range routes {
+//		g.Handle(r.Method, r.Path, r.Handler)
+//	}
+func (a *Analyzer) rangeStmt(ctx *Context, node *ast.RangeStmt, file *ast.File, pkg *packages.Package) bool {
+	lit, ok := ctx.ResolveExpr(node.X).(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	switch ctx.Package().TypesInfo.TypeOf(lit).Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+	default:
+		return false
+	}
+
+	for _, elt := range lit.Elts {
+		var key, value ast.Expr = nil, elt
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			key, value = kv.Key, kv.Value
+		}
+		elemCtx := ctx.Block()
+		bindLiteral(elemCtx.Env, node.Key, key)
+		bindLiteral(elemCtx.Env, node.Value, value)
+		a.blockStmt(elemCtx, node.Body, file, pkg)
+	}
+	return true
+}
+
+func bindLiteral(env *Environment, name ast.Expr, value ast.Expr) {
+	ident, ok := name.(*ast.Ident)
+	if !ok || ident.Name == "_" || value == nil {
+		return
+	}
+	env.Define(ident.Name, &LiteralValue{Expr: value})
+}
+
 func (a *Analyzer) parseGoModule(pkgPath string) *packages.Module {
 	dir, fileName := a.lookupGoModFile(pkgPath)
 	if fileName == "" {
@@ -401,14 +480,79 @@ func (a *Analyzer) context() *Context {
 }
 
 func (a *Analyzer) AddRoutes(items ...*API) {
+	if a.production {
+		items = lo.Filter(items, func(item *API, _ int) bool { return !item.Spec.hiddenInProduction })
+	}
 	a.routes.add(items...)
 
 	for _, item := range items {
-		path := a.doc.Paths[item.FullPath]
-		if path == nil {
-			path = &spec.PathItem{}
+		item.Spec.mergeComment()
+		a.applyRouteToDoc(item)
+	}
+}
+
+func (a *Analyzer) applyRouteToDoc(item *API) {
+	path := a.doc.Paths[item.FullPath]
+	if path == nil {
+		path = &spec.PathItem{}
+	}
+	item.applyToPathItem(path)
+	a.doc.Paths[item.FullPath] = path
+}
+
+// addRoutes 添加在 site 处声明的路由. root 为常规解析时 site 所在的顶层函数.
+// 如果 root 会通过 Context.AnalyzeFunc 进行跨函数解析, 那么常规解析时得到的路由分组信息是不完整的, 这些路由会被跨函数解析的结果替换.
+// 同一处路由声明可能经由不同的调用链被解析, 得到不同的路由, 这些路由都会被保留
+func (a *Analyzer) addRoutes(site ast.Node, root *ast.FuncDecl, items ...*API) {
+	if site == nil {
+		a.AddRoutes(items...)
+		return
+	}
+	if _, ok := a.enteredFuncs[root]; ok && root != nil {
+		return
+	}
+
+	for _, item := range items {
+		exists := lo.ContainsBy(a.siteRoutes, func(r *siteRoute) bool {
+			return r.site == site && r.api.Method == item.Method && r.api.FullPath == item.FullPath
+		})
+		if exists {
+			continue
+		}
+		a.siteRoutes = append(a.siteRoutes, &siteRoute{site: site, root: root, api: item})
+		a.AddRoutes(item)
+	}
+}
+
+// enterFunc 标记函数 decl 将通过 Context.AnalyzeFunc 进行跨函数解析, 移除常规解析时在该函数中得到的路由
+func (a *Analyzer) enterFunc(decl *ast.FuncDecl) {
+	if _, ok := a.enteredFuncs[decl]; ok {
+		return
+	}
+	a.enteredFuncs[decl] = struct{}{}
+
+	removed := lo.Filter(a.siteRoutes, func(r *siteRoute, _ int) bool { return r.root == decl })
+	a.siteRoutes = lo.Filter(a.siteRoutes, func(r *siteRoute, _ int) bool { return r.root != decl })
+	a.removeRoutes(lo.Map(removed, func(r *siteRoute, _ int) *API { return r.api })...)
+}
+
+func (a *Analyzer) removeRoutes(items ...*API) {
+	if len(items) == 0 {
+		return
+	}
+	a.routes = lo.Filter(a.routes, func(item *API, _ int) bool { return !lo.Contains(items, item) })
+	a.doc.Paths = make(spec.Paths)
+	for _, item := range a.routes {
+		a.applyRouteToDoc(item)
+	}
+}
+
+// funcDefinitionOf 查找函数声明对应的定义
+func (a *Analyzer) funcDefinitionOf(decl *ast.FuncDecl) *FuncDefinition {
+	for _, def := range a.definitions {
+		if def, ok := def.(*FuncDefinition); ok && def.Decl == decl {
+			return def
 		}
-		item.applyToPathItem(path)
-		a.doc.Paths[item.FullPath] = path
 	}
+	return nil
 }
@@ -14,6 +14,18 @@ const (
 	ID
 	Deprecated
 	Security
+	OneOf
+	Discriminator
+	Schema
+	Param
+	Response
+	Example
+	ExternalDocs
+	Server
+	Header
+	Callback
+	Extension
+	HiddenInProduction
 )
 
 type Annotation interface {
@@ -48,9 +60,12 @@ func (a *ProduceAnnotation) Type() Type {
 	return Produce
 }
 
+// UnresolvedAnnotation 未知的注解
 type UnresolvedAnnotation struct {
 	Tag    string
 	Tokens []*Token
+	// 注解标签在注释中的列号
+	Column int
 }
 
 func (a *UnresolvedAnnotation) Type() Type {
@@ -101,3 +116,114 @@ func newSecurityAnnotation(name string, params []string) *SecurityAnnotation {
 func (a *SecurityAnnotation) Type() Type {
 	return Security
 }
+
+type OneOfAnnotation struct {
+	Types []string
+}
+
+func (a *OneOfAnnotation) Type() Type {
+	return OneOf
+}
+
+type DiscriminatorAnnotation struct {
+	PropertyName string
+}
+
+func (a *DiscriminatorAnnotation) Type() Type {
+	return Discriminator
+}
+
+type SchemaAnnotation struct {
+	DataType string
+	Format   string
+}
+
+func (a *SchemaAnnotation) Type() Type {
+	return Schema
+}
+
+type ParamAnnotation struct {
+	Name        string
+	In          string // query | path | header | cookie
+	DataType    string
+	Required    bool
+	Description string
+}
+
+func (a *ParamAnnotation) Type() Type {
+	return Param
+}
+
+type ResponseAnnotation struct {
+	Status      string // 状态码或者 "default"
+	DataType    string // Go 类型表达式. e.g. "[]model.User"
+	Description string
+}
+
+func (a *ResponseAnnotation) Type() Type {
+	return Response
+}
+
+const (
+	ExampleTargetRequest  = "request"
+	ExampleTargetResponse = "response"
+)
+
+type ExampleAnnotation struct {
+	Target string // 为空时表示字段或者类型的示例, 否则为 request | response
+	Status string // Target 为 response 时的状态码或者 "default"
+	Value  string
+}
+
+func (a *ExampleAnnotation) Type() Type {
+	return Example
+}
+
+type ExternalDocsAnnotation struct {
+	URL         string
+	Description string
+}
+
+func (a *ExternalDocsAnnotation) Type() Type {
+	return ExternalDocs
+}
+
+type ServerAnnotation struct {
+	URL         string
+	Description string
+}
+
+func (a *ServerAnnotation) Type() Type {
+	return Server
+}
+
+type HeaderAnnotation struct {
+	Status      string // 状态码或者 "default"
+	Name        string
+	DataType    string
+	Description string
+}
+
+func (a *HeaderAnnotation) Type() Type {
+	return Header
+}
+
+type CallbackAnnotation struct {
+	Name     string
+	Method   string
+	URL      string
+	DataType string // 回调请求体的 Go 类型表达式
+}
+
+func (a *CallbackAnnotation) Type() Type {
+	return Callback
+}
+
+type ExtensionAnnotation struct {
+	Name  string // e.g. "x-codegen"
+	Value string
+}
+
+func (a *ExtensionAnnotation) Type() Type {
+	return Extension
+}
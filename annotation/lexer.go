@@ -17,7 +17,7 @@ const (
 )
 
 var patterns = []*pattern{
-	newPattern(tokenTag, "^@[a-zA-Z_]+\\w*"),
+	newPattern(tokenTag, "^@[a-zA-Z_][\\w-]*"),
 	newPattern(tokenString, "^\"(\\\\.|[^\"])*\""),
 	newPattern(tokenNumber, "^[+-]?([0-9]*[.])?[0-9]+"),
 	newPattern(tokenBool, "/^(true|false)/i"),
@@ -71,7 +71,7 @@ func (l *Lexer) Lex() (tokens []*Token, err error) {
 			}
 		}
 		if matched == "" {
-			err = fmt.Errorf("unexpected token after %s", l.code[:cursor])
+			err = NewParseError(cursor, fmt.Sprintf("unexpected token after %s", l.code[:cursor]))
 			return
 		}
 
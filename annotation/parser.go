@@ -2,6 +2,7 @@ package annotation
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -40,7 +41,11 @@ func (p *Parser) Parse() (Annotation, error) {
 
 	tokens, err := NewLexer(text).Lex()
 	if err != nil {
-		return nil, nil
+		if err, ok := err.(*ParseError); ok {
+			err.Column += column
+			return nil, err
+		}
+		return nil, NewParseError(column, err.Error())
 	}
 	if len(tokens) == 0 {
 		return nil, nil
@@ -56,45 +61,62 @@ func (p *Parser) Parse() (Annotation, error) {
 func (p *Parser) parse() (Annotation, error) {
 	tag, err := p.consume(tokenTag)
 	if err != nil {
+		// 以 @ 开头但不是合法的注解标签. e.g. "@123"
+		if t := p.lookahead(); t != nil && strings.HasPrefix(t.Image, "@") {
+			return nil, NewParseError(p.column, fmt.Sprintf("invalid annotation '%s'", t.Image))
+		}
 		return nil, nil
 	}
 
-	switch strings.ToLower(tag.Image) {
-	case "@required":
-		return newSimpleAnnotation(Required), nil
-	case "@consume":
-		return p.consumeAnnotation()
-	case "@produce":
-		return p.produceAnnotation()
-	case "@ignore":
-		return newSimpleAnnotation(Ignore), nil
-	case "@tag", "@tags":
-		return p.tags(), nil
-	case "@description":
-		return p.description(), nil
-	case "@summary":
-		return p.summary(), nil
-	case "@id":
-		return p.id(), nil
-	case "@deprecated":
-		return newSimpleAnnotation(Deprecated), nil
-	case "@security":
-		return p.security()
-	default: // unresolved plugin
-		return p.unresolved(tag), nil
+	for _, item := range knownTags {
+		if strings.EqualFold(item.tag, tag.Image) {
+			return item.parse(p)
+		}
 	}
+	if strings.HasPrefix(strings.ToLower(tag.Image), "@x-") {
+		return p.extension(tag)
+	}
+	// unresolved plugin
+	return p.unresolved(tag), nil
 }
 
-func (p *Parser) consume(typ TokenType) (*Token, error) {
-	for {
-		t := p.lookahead()
-		if t != nil && t.Type == tokenWhiteSpace {
-			p.position += 1
-			p.column += len(t.Image)
-		} else {
-			break
-		}
+// knownTags 支持的注解标签及其解析函数. 标签匹配时忽略大小写, 同时用于在遇到未知的注解时给出建议
+var knownTags = []struct {
+	tag   string
+	parse func(p *Parser) (Annotation, error)
+}{
+	{"@required", simpleTag(Required)},
+	{"@consume", func(p *Parser) (Annotation, error) { return p.consumeAnnotation() }},
+	{"@produce", func(p *Parser) (Annotation, error) { return p.produceAnnotation() }},
+	{"@ignore", simpleTag(Ignore)},
+	{"@tag", func(p *Parser) (Annotation, error) { return p.tags(), nil }},
+	{"@tags", func(p *Parser) (Annotation, error) { return p.tags(), nil }},
+	{"@description", func(p *Parser) (Annotation, error) { return p.description(), nil }},
+	{"@summary", func(p *Parser) (Annotation, error) { return p.summary(), nil }},
+	{"@id", func(p *Parser) (Annotation, error) { return p.id(), nil }},
+	{"@deprecated", simpleTag(Deprecated)},
+	{"@security", func(p *Parser) (Annotation, error) { return p.security() }},
+	{"@oneOf", func(p *Parser) (Annotation, error) { return p.oneOf() }},
+	{"@discriminator", func(p *Parser) (Annotation, error) { return p.discriminator() }},
+	{"@schema", func(p *Parser) (Annotation, error) { return p.schema() }},
+	{"@param", func(p *Parser) (Annotation, error) { return p.param() }},
+	{"@response", func(p *Parser) (Annotation, error) { return p.response() }},
+	{"@example", func(p *Parser) (Annotation, error) { return p.example() }},
+	{"@externalDocs", func(p *Parser) (Annotation, error) { return p.externalDocs() }},
+	{"@server", func(p *Parser) (Annotation, error) { return p.server() }},
+	{"@header", func(p *Parser) (Annotation, error) { return p.header() }},
+	{"@callback", func(p *Parser) (Annotation, error) { return p.callback() }},
+	{"@hidden-in-production", simpleTag(HiddenInProduction)},
+}
+
+func simpleTag(t Type) func(p *Parser) (Annotation, error) {
+	return func(p *Parser) (Annotation, error) {
+		return newSimpleAnnotation(t), nil
 	}
+}
+
+func (p *Parser) consume(typ TokenType) (*Token, error) {
+	p.skipWhiteSpace()
 
 	t := p.lookahead()
 	if t == nil {
@@ -109,6 +131,18 @@ func (p *Parser) consume(typ TokenType) (*Token, error) {
 	return t, nil
 }
 
+func (p *Parser) skipWhiteSpace() {
+	for {
+		t := p.lookahead()
+		if t != nil && t.Type == tokenWhiteSpace {
+			p.position += 1
+			p.column += len(t.Image)
+		} else {
+			break
+		}
+	}
+}
+
 func (p *Parser) consumeAny() *Token {
 	t := p.lookahead()
 	if t == nil {
@@ -158,6 +192,7 @@ func (p *Parser) unresolved(tag *Token) Annotation {
 	return &UnresolvedAnnotation{
 		Tag:    tag.Image,
 		Tokens: p.tokens,
+		Column: p.column - len(tag.Image),
 	}
 }
 
@@ -220,3 +255,227 @@ func (p *Parser) security() (*SecurityAnnotation, error) {
 
 	return &security, nil
 }
+
+// @oneOf Type1 [pkg.Type2 ...]
+func (p *Parser) oneOf() (*OneOfAnnotation, error) {
+	var res OneOfAnnotation
+	for p.hasMore() {
+		token := p.consumeAny()
+		if token.Type == tokenIdentifier {
+			res.Types = append(res.Types, token.Image)
+		}
+	}
+	if len(res.Types) == 0 {
+		return nil, NewParseError(p.column, "expect type names after @oneOf")
+	}
+	return &res, nil
+}
+
+// @discriminator propertyName
+func (p *Parser) discriminator() (*DiscriminatorAnnotation, error) {
+	name, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect property name after @discriminator")
+	}
+	return &DiscriminatorAnnotation{PropertyName: name.Image}, nil
+}
+
+// @schema type [format]
+func (p *Parser) schema() (*SchemaAnnotation, error) {
+	dataType, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect type after @schema")
+	}
+	res := &SchemaAnnotation{DataType: dataType.Image}
+	if format, err := p.consume(tokenIdentifier); err == nil {
+		res.Format = format.Image
+	}
+	return res, nil
+}
+
+// @param name in type [required] [description]
+func (p *Parser) param() (*ParamAnnotation, error) {
+	name, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect name after @param")
+	}
+	in, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect location (query, path, header or cookie) after parameter name")
+	}
+	switch strings.ToLower(in.Image) {
+	case "query", "path", "header", "cookie":
+	default:
+		return nil, NewParseError(p.column-len(in.Image), fmt.Sprintf("invalid parameter location '%s'", in.Image))
+	}
+	dataType, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect type after parameter location")
+	}
+
+	res := &ParamAnnotation{
+		Name:     name.Image,
+		In:       strings.ToLower(in.Image),
+		DataType: dataType.Image,
+	}
+	p.skipWhiteSpace()
+	if t := p.lookahead(); t != nil && t.Type == tokenIdentifier && strings.EqualFold(t.Image, "required") {
+		p.consumeAny()
+		res.Required = true
+	}
+	res.Description = p.rest()
+	return res, nil
+}
+
+// @response status|default [type] ["description"]
+func (p *Parser) response() (*ResponseAnnotation, error) {
+	p.skipWhiteSpace()
+	column := p.column
+	status := p.consumeAny()
+	if status == nil {
+		return nil, NewParseError(p.column, "expect status code after @response")
+	}
+	res := &ResponseAnnotation{Status: strings.ToLower(status.Image)}
+	if res.Status != "default" {
+		code, err := strconv.Atoi(status.Image)
+		if err != nil || code < 100 || code > 599 {
+			return nil, NewParseError(column, fmt.Sprintf("invalid status code '%s'", status.Image))
+		}
+	}
+
+	p.skipWhiteSpace()
+	if t := p.lookahead(); t != nil && t.Type == tokenIdentifier {
+		res.DataType = p.consumeAny().Image
+	}
+	res.Description = p.rest()
+	return res, nil
+}
+
+// rest 返回剩余的文本. 使用双引号包裹的文本会被去掉引号
+func (p *Parser) rest() string {
+	var text string
+	for p.hasMore() {
+		text += p.consumeAny().Image
+	}
+	text = strings.TrimSpace(text)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		return unquoted
+	}
+	return text
+}
+
+// @example [request | response [status|default]] value
+func (p *Parser) example() (*ExampleAnnotation, error) {
+	res := &ExampleAnnotation{}
+	p.skipWhiteSpace()
+	if t := p.lookahead(); t != nil && t.Type == tokenIdentifier {
+		switch strings.ToLower(t.Image) {
+		case ExampleTargetRequest:
+			p.consumeAny()
+			res.Target = ExampleTargetRequest
+		case ExampleTargetResponse:
+			p.consumeAny()
+			res.Target = ExampleTargetResponse
+			res.Status = "200"
+			p.skipWhiteSpace()
+			if t := p.lookahead(); t != nil && t.Type == tokenNumber {
+				res.Status = p.consumeAny().Image
+			} else if t != nil && strings.EqualFold(t.Image, "default") {
+				p.consumeAny()
+				res.Status = "default"
+			}
+		}
+	}
+	for p.hasMore() {
+		res.Value += p.consumeAny().Image
+	}
+	res.Value = strings.TrimSpace(res.Value)
+	if res.Value == "" {
+		return nil, NewParseError(p.column, "expect value after @example")
+	}
+	return res, nil
+}
+
+// @externalDocs url [description]
+func (p *Parser) externalDocs() (*ExternalDocsAnnotation, error) {
+	url, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect url after @externalDocs")
+	}
+	return &ExternalDocsAnnotation{URL: url.Image, Description: p.rest()}, nil
+}
+
+// @server url [description]
+func (p *Parser) server() (*ServerAnnotation, error) {
+	url, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect url after @server")
+	}
+	return &ServerAnnotation{URL: url.Image, Description: p.rest()}, nil
+}
+
+// @header status|default name type [description]
+func (p *Parser) header() (*HeaderAnnotation, error) {
+	p.skipWhiteSpace()
+	column := p.column
+	status := p.consumeAny()
+	if status == nil {
+		return nil, NewParseError(p.column, "expect status code after @header")
+	}
+	res := &HeaderAnnotation{Status: strings.ToLower(status.Image)}
+	if res.Status != "default" {
+		code, err := strconv.Atoi(status.Image)
+		if err != nil || code < 100 || code > 599 {
+			return nil, NewParseError(column, fmt.Sprintf("invalid status code '%s'", status.Image))
+		}
+	}
+	name, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect header name after status code")
+	}
+	dataType, err := p.consume(tokenIdentifier)
+	if err != nil {
+		return nil, NewParseError(p.column, "expect type after header name")
+	}
+	res.Name = name.Image
+	res.DataType = dataType.Image
+	res.Description = p.rest()
+	return res, nil
+}
+
+// @callback name method url type
+func (p *Parser) callback() (*CallbackAnnotation, error) {
+	var values []string
+	for _, expect := range []string{"name", "method", "url", "type"} {
+		token, err := p.consume(tokenIdentifier)
+		if err != nil {
+			return nil, NewParseError(p.column, fmt.Sprintf("expect %s of @callback", expect))
+		}
+		values = append(values, token.Image)
+	}
+	method := strings.ToUpper(values[1])
+	switch method {
+	case "GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "TRACE":
+	default:
+		return nil, NewParseError(p.column, fmt.Sprintf("invalid method '%s' of @callback", values[1]))
+	}
+	return &CallbackAnnotation{
+		Name:     values[0],
+		Method:   method,
+		URL:      values[2],
+		DataType: values[3],
+	}, nil
+}
+
+// @x-name value
+func (p *Parser) extension(tag *Token) (*ExtensionAnnotation, error) {
+	res := &ExtensionAnnotation{Name: strings.TrimPrefix(tag.Image, "@")}
+	for p.hasMore() {
+		res.Value += p.consumeAny().Image
+	}
+	res.Value = strings.TrimSpace(res.Value)
+	if res.Value == "" {
+		return nil, NewParseError(p.column, fmt.Sprintf("expect value after %s", tag.Image))
+	}
+	return res, nil
+}
@@ -43,6 +43,212 @@ func TestParser_Parse(t *testing.T) {
 			wantErr: true,
 			want:    (*SecurityAnnotation)(nil),
 		},
+		{
+			name: "oneOf",
+			code: " @oneOf Circle shape.Rect",
+			want: &OneOfAnnotation{Types: []string{"Circle", "shape.Rect"}},
+		},
+		{
+			name:    "oneOf error",
+			code:    "@oneOf",
+			wantErr: true,
+			want:    (*OneOfAnnotation)(nil),
+		},
+		{
+			name: "discriminator",
+			code: "@discriminator kind",
+			want: &DiscriminatorAnnotation{PropertyName: "kind"},
+		},
+		{
+			name: "schema",
+			code: "@schema string date-time",
+			want: &SchemaAnnotation{DataType: "string", Format: "date-time"},
+		},
+		{
+			name: "schema without format",
+			code: "@schema integer",
+			want: &SchemaAnnotation{DataType: "integer"},
+		},
+		{
+			name:    "schema error",
+			code:    "@schema",
+			wantErr: true,
+			want:    (*SchemaAnnotation)(nil),
+		},
+		{
+			name: "param",
+			code: "@param page query integer required 页码",
+			want: &ParamAnnotation{Name: "page", In: "query", DataType: "integer", Required: true, Description: "页码"},
+		},
+		{
+			name: "param with quoted description",
+			code: `@param X-Trace-Id HEADER string "trace id of request"`,
+			want: &ParamAnnotation{Name: "X-Trace-Id", In: "header", DataType: "string", Description: "trace id of request"},
+		},
+		{
+			name: "param without description",
+			code: "@param ids query []int",
+			want: &ParamAnnotation{Name: "ids", In: "query", DataType: "[]int"},
+		},
+		{
+			name:    "param invalid location",
+			code:    "@param page body integer",
+			wantErr: true,
+			want:    (*ParamAnnotation)(nil),
+		},
+		{
+			name:    "param error",
+			code:    "@param page query",
+			wantErr: true,
+			want:    (*ParamAnnotation)(nil),
+		},
+		{
+			name: "response",
+			code: `@response 404 errs.NotFound "user missing"`,
+			want: &ResponseAnnotation{Status: "404", DataType: "errs.NotFound", Description: "user missing"},
+		},
+		{
+			name: "response without description",
+			code: "@response 200 []model.User",
+			want: &ResponseAnnotation{Status: "200", DataType: "[]model.User"},
+		},
+		{
+			name: "response default",
+			code: `@response DEFAULT "unexpected error"`,
+			want: &ResponseAnnotation{Status: "default", Description: "unexpected error"},
+		},
+		{
+			name:    "response invalid status",
+			code:    "@response 99 model.User",
+			wantErr: true,
+			want:    (*ResponseAnnotation)(nil),
+		},
+		{
+			name:    "response error",
+			code:    "@response",
+			wantErr: true,
+			want:    (*ResponseAnnotation)(nil),
+		},
+		{
+			name: "example",
+			code: `@example {"name": "iPhone", "price": 100}`,
+			want: &ExampleAnnotation{Value: `{"name": "iPhone", "price": 100}`},
+		},
+		{
+			name: "example request",
+			code: `@example request {"guid": "123"}`,
+			want: &ExampleAnnotation{Target: "request", Value: `{"guid": "123"}`},
+		},
+		{
+			name: "example response",
+			code: `@example response 404 {"code": 10001}`,
+			want: &ExampleAnnotation{Target: "response", Status: "404", Value: `{"code": 10001}`},
+		},
+		{
+			name: "example response without status",
+			code: `@example Response ["a", "b"]`,
+			want: &ExampleAnnotation{Target: "response", Status: "200", Value: `["a", "b"]`},
+		},
+		{
+			name:    "example error",
+			code:    "@example response 200",
+			wantErr: true,
+			want:    (*ExampleAnnotation)(nil),
+		},
+		{
+			name: "externalDocs",
+			code: `@externalDocs https://example.com/docs/goods "商品文档"`,
+			want: &ExternalDocsAnnotation{URL: "https://example.com/docs/goods", Description: "商品文档"},
+		},
+		{
+			name:    "externalDocs error",
+			code:    "@externalDocs",
+			wantErr: true,
+			want:    (*ExternalDocsAnnotation)(nil),
+		},
+		{
+			name: "server",
+			code: "@server https://upload.example.com",
+			want: &ServerAnnotation{URL: "https://upload.example.com"},
+		},
+		{
+			name:    "server error",
+			code:    "@server",
+			wantErr: true,
+			want:    (*ServerAnnotation)(nil),
+		},
+		{
+			name: "header",
+			code: "@header 200 X-Total-Count integer 总数",
+			want: &HeaderAnnotation{Status: "200", Name: "X-Total-Count", DataType: "integer", Description: "总数"},
+		},
+		{
+			name:    "header invalid status",
+			code:    "@header ok X-Total-Count integer",
+			wantErr: true,
+			want:    (*HeaderAnnotation)(nil),
+		},
+		{
+			name:    "header error",
+			code:    "@header 200 X-Total-Count",
+			wantErr: true,
+			want:    (*HeaderAnnotation)(nil),
+		},
+		{
+			name: "callback",
+			code: "@callback paid post {$request.body#/callbackUrl} view.PaidEvent",
+			want: &CallbackAnnotation{Name: "paid", Method: "POST", URL: "{$request.body#/callbackUrl}", DataType: "view.PaidEvent"},
+		},
+		{
+			name:    "callback invalid method",
+			code:    "@callback paid send {$request.body#/callbackUrl} view.PaidEvent",
+			wantErr: true,
+			want:    (*CallbackAnnotation)(nil),
+		},
+		{
+			name:    "callback error",
+			code:    "@callback paid post",
+			wantErr: true,
+			want:    (*CallbackAnnotation)(nil),
+		},
+		{
+			name: "hidden in production",
+			code: " @hidden-in-production",
+			want: newSimpleAnnotation(HiddenInProduction),
+		},
+		{
+			name: "extension",
+			code: `@x-codegen-request-body-name {"name": "body"}`,
+			want: &ExtensionAnnotation{Name: "x-codegen-request-body-name", Value: `{"name": "body"}`},
+		},
+		{
+			name:    "extension error",
+			code:    "@x-internal",
+			wantErr: true,
+			want:    (*ExtensionAnnotation)(nil),
+		},
+		{
+			name: "unresolved",
+			code: "// @requried",
+			want: &UnresolvedAnnotation{Tag: "@requried", Tokens: []*Token{{Type: tokenWhiteSpace, Image: " "}, {Type: tokenTag, Image: "@requried"}}, Column: 3},
+		},
+		{
+			name:    "invalid annotation",
+			code:    "// @123",
+			wantErr: true,
+			want:    nil,
+		},
+		{
+			name: "plain text",
+			code: "// 商品列表",
+			want: nil,
+		},
+		{
+			name:    "discriminator error",
+			code:    "@discriminator",
+			wantErr: true,
+			want:    (*DiscriminatorAnnotation)(nil),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,38 @@
+package annotation
+
+import "strings"
+
+// Suggest 返回和 tag 最相似的已知注解标签. 找不到相似的标签时返回空字符串
+func Suggest(tag string) string {
+	var (
+		res     string
+		minDist = 3 // 最多允许 2 个字符的差异
+	)
+	for _, known := range knownTags {
+		dist := levenshtein(strings.ToLower(tag), strings.ToLower(known.tag))
+		if dist < minDist {
+			res, minDist = known.tag, dist
+		}
+	}
+	return res
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,24 @@
+package annotation
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "@requried", want: "@required"},
+		{tag: "@secuirty", want: "@security"},
+		{tag: "@ONEOF", want: "@oneOf"},
+		{tag: "@responses", want: "@response"},
+		{tag: "@tga", want: "@tag"},
+		{tag: "@author", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := Suggest(tt.tag); got != tt.want {
+				t.Errorf("Suggest(%s) = %s, want %s", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
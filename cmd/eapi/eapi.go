@@ -4,13 +4,23 @@ import (
 	"os"
 
 	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/chi"
 	"github.com/gotomicro/eapi/plugins/echo"
+	"github.com/gotomicro/eapi/plugins/ego"
+	"github.com/gotomicro/eapi/plugins/fiber"
 	"github.com/gotomicro/eapi/plugins/gin"
+	"github.com/gotomicro/eapi/plugins/grpcgateway"
+	"github.com/gotomicro/eapi/plugins/stdhttp"
 )
 
 func main() {
 	eapi.NewEntrypoint(
 		gin.NewPlugin(),
 		echo.NewPlugin(),
+		stdhttp.NewPlugin(),
+		chi.NewPlugin(),
+		fiber.NewPlugin(),
+		grpcgateway.NewPlugin(),
+		ego.NewPlugin(),
 	).Run(os.Args)
 }
@@ -1,10 +1,10 @@
 package eapi
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/ast"
 	"go/token"
-	"os"
 	"strings"
 
 	"github.com/gotomicro/eapi/annotation"
@@ -15,6 +15,18 @@ import (
 type Comment struct {
 	text        string
 	Annotations []annotation.Annotation
+	// 解析注释时发现的注解问题
+	Diagnostics []*Diagnostic
+	// 注解在源码中的位置
+	positions map[annotation.Annotation]token.Position
+}
+
+// Position 获取注解在源码中的位置
+func (c *Comment) Position(annot annotation.Annotation) token.Position {
+	if c == nil {
+		return token.Position{}
+	}
+	return c.positions[annot]
 }
 
 func (c *Comment) Text() string {
@@ -64,6 +76,19 @@ func (c *Comment) Deprecated() bool {
 	return false
 }
 
+// HiddenInProduction 是否声明了 @hidden-in-production
+func (c *Comment) HiddenInProduction() bool {
+	if c == nil {
+		return false
+	}
+	for _, a := range c.Annotations {
+		if a.Type() == annotation.HiddenInProduction {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Comment) ApplyToSchema(schema *spec.SchemaRef) {
 	if c == nil || schema == nil {
 		return
@@ -80,6 +105,36 @@ func (c *Comment) ApplyToSchema(schema *spec.SchemaRef) {
 	}
 	value.Description = c.Text()
 	value.Deprecated = c.Deprecated()
+	if example, ok := c.Example(); ok {
+		value.Example = annotationValue(example, value.Type)
+	}
+}
+
+// Example 获取字段或者类型注释中 @example 注解声明的示例
+func (c *Comment) Example() (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.ExampleAnnotation); ok && annot.Target == "" {
+			return annot.Value, true
+		}
+	}
+	return "", false
+}
+
+// OperationExamples 获取 handler 注释中 @example request/response 注解声明的示例
+func (c *Comment) OperationExamples() []*annotation.ExampleAnnotation {
+	if c == nil {
+		return nil
+	}
+	var res []*annotation.ExampleAnnotation
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.ExampleAnnotation); ok && annot.Target != "" {
+			res = append(res, annot)
+		}
+	}
+	return res
 }
 
 func (c *Comment) Consumes() []string {
@@ -157,6 +212,53 @@ func (c *Comment) ID() string {
 	return ""
 }
 
+// OneOf 获取 @oneOf 注解中声明的类型名称
+func (c *Comment) OneOf() []string {
+	if c == nil {
+		return nil
+	}
+	var res []string
+	for _, annot := range c.Annotations {
+		if oneOf, ok := annot.(*annotation.OneOfAnnotation); ok {
+			res = append(res, oneOf.Types...)
+		}
+	}
+	return res
+}
+
+// Schema 获取 @schema 注解声明的 schema. e.g. @schema string date-time
+func (c *Comment) Schema() *spec.Schema {
+	if c == nil {
+		return nil
+	}
+	for _, annot := range c.Annotations {
+		annot, ok := annot.(*annotation.SchemaAnnotation)
+		if !ok {
+			continue
+		}
+		switch annot.DataType {
+		case "any", spec.TypeObject:
+			return spec.NewObjectSchema().WithExtendedType(spec.NewAnyExtendedType())
+		default:
+			return spec.NewSchema().WithType(annot.DataType).WithFormat(annot.Format)
+		}
+	}
+	return nil
+}
+
+// Discriminator 获取 @discriminator 注解中声明的属性名称
+func (c *Comment) Discriminator() string {
+	if c == nil {
+		return ""
+	}
+	for _, annot := range c.Annotations {
+		if discriminator, ok := annot.(*annotation.DiscriminatorAnnotation); ok {
+			return discriminator.PropertyName
+		}
+	}
+	return ""
+}
+
 func (c *Comment) Security() *spec.SecurityRequirements {
 	if c == nil {
 		return nil
@@ -165,6 +267,140 @@ func (c *Comment) Security() *spec.SecurityRequirements {
 	return convertSecAnnotationToSecurityRequirements(c.Annotations)
 }
 
+// Params 获取 @param 注解中声明的参数
+func (c *Comment) Params() []*spec.Parameter {
+	if c == nil {
+		return nil
+	}
+	var res []*spec.Parameter
+	for _, annot := range c.Annotations {
+		annot, ok := annot.(*annotation.ParamAnnotation)
+		if !ok {
+			continue
+		}
+		param := &spec.Parameter{In: annot.In, Name: annot.Name}
+		param.Required = annot.Required || annot.In == spec.ParameterInPath
+		param.Description = annot.Description
+		param.Schema = annotationDataSchema(annot.DataType)
+		res = append(res, param)
+	}
+	return res
+}
+
+// Responses 获取 @response 注解
+func (c *Comment) Responses() []*annotation.ResponseAnnotation {
+	if c == nil {
+		return nil
+	}
+	var res []*annotation.ResponseAnnotation
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.ResponseAnnotation); ok {
+			res = append(res, annot)
+		}
+	}
+	return res
+}
+
+// annotationValue 解析 @example/@x- 等注解中的值. 合法的 JSON 使用解析之后的值, 否则作为字符串.
+// 字符串类型的 schema 只有使用双引号时才会作为 JSON 解析, 避免 "123" 之类的示例被解析为数字
+func annotationValue(raw string, dataType string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return raw
+	}
+	if _, ok := value.(string); !ok && dataType == spec.TypeString {
+		return raw
+	}
+	return value
+}
+
+// ExternalDocs 获取 @externalDocs 注解声明的外部文档
+func (c *Comment) ExternalDocs() *spec.ExternalDocs {
+	if c == nil {
+		return nil
+	}
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.ExternalDocsAnnotation); ok {
+			return &spec.ExternalDocs{URL: annot.URL, Description: annot.Description}
+		}
+	}
+	return nil
+}
+
+// Servers 获取 @server 注解声明的服务地址
+func (c *Comment) Servers() spec.Servers {
+	if c == nil {
+		return nil
+	}
+	var res spec.Servers
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.ServerAnnotation); ok {
+			res = append(res, &spec.Server{URL: annot.URL, Description: annot.Description})
+		}
+	}
+	return res
+}
+
+// Headers 获取 @header 注解
+func (c *Comment) Headers() []*annotation.HeaderAnnotation {
+	if c == nil {
+		return nil
+	}
+	var res []*annotation.HeaderAnnotation
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.HeaderAnnotation); ok {
+			res = append(res, annot)
+		}
+	}
+	return res
+}
+
+// Callbacks 获取 @callback 注解
+func (c *Comment) Callbacks() []*annotation.CallbackAnnotation {
+	if c == nil {
+		return nil
+	}
+	var res []*annotation.CallbackAnnotation
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.CallbackAnnotation); ok {
+			res = append(res, annot)
+		}
+	}
+	return res
+}
+
+// Extensions 获取 @x-<name> 注解声明的扩展字段
+func (c *Comment) Extensions() map[string]interface{} {
+	if c == nil {
+		return nil
+	}
+	res := make(map[string]interface{})
+	for _, annot := range c.Annotations {
+		if annot, ok := annot.(*annotation.ExtensionAnnotation); ok {
+			res[annot.Name] = annotationValue(annot.Value, "")
+		}
+	}
+	return res
+}
+
+// annotationDataSchema 将注解中的类型转换为 schema. 支持 OpenAPI 的基础类型, Go 的基础类型以及 "[]T" 形式的数组
+func annotationDataSchema(dataType string) *spec.Schema {
+	if elem, ok := strings.CutPrefix(dataType, "[]"); ok {
+		return spec.NewArraySchema(annotationDataSchema(elem))
+	}
+	switch dataType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return spec.NewIntegerSchema()
+	case "float32", "float64":
+		return spec.NewFloat64Schema()
+	case "bool":
+		return spec.NewBoolSchema()
+	case "file":
+		return spec.NewSchema().WithType(spec.TypeString).WithFormat("binary")
+	}
+	return spec.NewSchema().WithType(dataType)
+}
+
 func convertSecAnnotationToSecurityRequirements(annotations []annotation.Annotation) *spec.SecurityRequirements {
 	ret := spec.NewSecurityRequirements()
 	for _, annot := range annotations {
@@ -184,18 +420,36 @@ func ParseComment(commentGroup *ast.CommentGroup, fSet *token.FileSet) *Comment
 	if commentGroup == nil {
 		return nil
 	}
-	c := &Comment{}
+	c := &Comment{positions: make(map[annotation.Annotation]token.Position)}
 	var lines []string
 	var descriptions []*annotation.DescriptionAnnotation
 	for _, comment := range commentGroup.List {
 		annot, err := annotation.NewParser(comment.Text).Parse()
 		if err != nil {
-			err := err.(*annotation.ParseError)
-			fmt.Fprintf(os.Stderr, "[Invalid Annotation]: %s at %s\n", err, fSet.Position(comment.Pos()+token.Pos(err.Column)).String())
+			column := 0
+			if err, ok := err.(*annotation.ParseError); ok {
+				column = err.Column
+			}
+			c.Diagnostics = append(c.Diagnostics, &Diagnostic{
+				Position: fSet.Position(comment.Pos() + token.Pos(column)),
+				Message:  err.Error(),
+			})
 			continue
 		}
+		if unresolved, ok := annot.(*annotation.UnresolvedAnnotation); ok {
+			message := fmt.Sprintf("unknown annotation '%s'", unresolved.Tag)
+			if suggestion := annotation.Suggest(unresolved.Tag); suggestion != "" {
+				message += fmt.Sprintf(", did you mean '%s'?", suggestion)
+			}
+			c.Diagnostics = append(c.Diagnostics, &Diagnostic{
+				Position:   fSet.Position(comment.Pos() + token.Pos(unresolved.Column)),
+				Message:    message,
+				unknownTag: unresolved.Tag,
+			})
+		}
 		if annot != nil {
 			c.Annotations = append(c.Annotations, annot)
+			c.positions[annot] = fSet.Position(comment.Pos() + token.Pos(strings.Index(comment.Text, "@")))
 			desc, ok := annot.(*annotation.DescriptionAnnotation)
 			if ok {
 				descriptions = append(descriptions, desc)
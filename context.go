@@ -3,12 +3,15 @@ package eapi
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"path"
 	"strconv"
 
 	"github.com/gotomicro/eapi/spec"
 	"github.com/gotomicro/eapi/utils"
+	"github.com/samber/lo"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -21,6 +24,12 @@ type Context struct {
 	file         *ast.File
 	analyzer     *Analyzer
 	commentStack *CommentStack
+	// 当前正在解析的节点
+	node ast.Node
+	// 通过 AnalyzeFunc 进入的函数调用栈
+	callStack []*ast.FuncDecl
+	// 常规解析时所在的顶层函数
+	root *ast.FuncDecl
 }
 
 func newContext(analyzer *Analyzer, env *Environment) *Context {
@@ -90,10 +99,106 @@ func (c *Context) Doc() *spec.T {
 }
 
 func (c *Context) AddAPI(items ...*API) {
-	c.analyzer.AddRoutes(items...)
+	c.analyzer.addRoutes(c.node, c.root, items...)
+}
+
+const maxCallDepth = 16
+
+// AnalyzeFunc 在新的环境中解析函数 def 的函数体. bind 用于在解析之前向环境中注入信息, 比如函数参数对应的路由分组.
+// 用于跨函数/跨包传递路由分组等信息. 在此过程中注册的路由会替换掉常规解析时在函数 def 中注册的路由
+func (c *Context) AnalyzeFunc(def *FuncDefinition, bind func(env *Environment)) {
+	ctx := c.enterFunc(def)
+	if ctx == nil {
+		return
+	}
+	c.analyzer.enterFunc(def.Decl)
+	if bind != nil {
+		bind(ctx.Env)
+	}
+	c.analyzer.funDecl(ctx, def.Decl, def.File(), def.Pkg())
+}
+
+// EnterFunc 返回用于解析函数 def 函数体的上下文, 函数参数绑定到 call 中对应的实参. e.g.
+//
+//	resp.OK(c, data) => 解析 resp.OK 时, 参数 data 对应调用处的 data
+//
+// 递归调用或者调用层级过深时返回 nil
+func (c *Context) EnterFunc(def *FuncDefinition, call *ast.CallExpr) *Context {
+	ctx := c.enterFunc(def)
+	if ctx == nil {
+		return nil
+	}
+	if call.Ellipsis.IsValid() { // f(c, args...)
+		return ctx
+	}
+	var index int
+	for _, field := range def.Decl.Type.Params.List {
+		for _, name := range field.Names {
+			if index >= len(call.Args) {
+				return ctx
+			}
+			if obj, ok := def.Pkg().TypesInfo.Defs[name].(*types.Var); ok {
+				ctx.Env.Define(obj, &ArgValue{Expr: call.Args[index], ctx: c})
+			}
+			index++
+		}
+		if len(field.Names) == 0 {
+			index++
+		}
+	}
+	return ctx
+}
+
+func (c *Context) enterFunc(def *FuncDefinition) *Context {
+	if def == nil || def.Decl.Body == nil || len(c.callStack) >= maxCallDepth {
+		return nil
+	}
+	for _, decl := range c.callStack {
+		if decl == def.Decl { // recursive call
+			return nil
+		}
+	}
+
+	ctx := c.NewEnv().WithPackage(def.Pkg()).WithFile(def.File()).Block()
+	ctx.callStack = append(append([]*ast.FuncDecl{}, c.callStack...), def.Decl)
+	return ctx
+}
+
+// ResolveArg 将通过 EnterFunc 绑定了实参的函数参数替换为实参表达式, 同时返回实参所在的上下文
+func (c *Context) ResolveArg(expr ast.Expr) (*Context, ast.Expr) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return c.ResolveArg(e.X)
+	case *ast.Ident:
+		obj, ok := c.Package().TypesInfo.ObjectOf(e).(*types.Var)
+		if !ok {
+			break
+		}
+		if arg, ok := c.Env.Lookup(obj).(*ArgValue); ok {
+			return arg.ctx.ResolveArg(arg.Expr)
+		}
+	}
+	return c, expr
+}
+
+// GetFuncDefinition 获取表达式 (函数名/方法名/函数调用) 对应的函数定义
+func (c *Context) GetFuncDefinition(expr ast.Expr) *FuncDefinition {
+	if call, ok := expr.(*ast.CallExpr); ok {
+		expr = call.Fun
+	}
+	fn := c.GetFuncFromAstNode(expr)
+	if fn == nil {
+		return nil
+	}
+	typeName, methodName := utils.GetFuncInfo(fn)
+	def, _ := c.GetDefinition(typeName, methodName).(*FuncDefinition)
+	return def
 }
 
 func (c *Context) ParseStatusCode(status ast.Expr) int {
+	if ctx, arg := c.ResolveArg(status); arg != status {
+		return ctx.ParseStatusCode(arg)
+	}
 	switch status := status.(type) {
 	case *ast.SelectorExpr:
 		return c.ParseStatusCode(status.Sel)
@@ -136,6 +241,196 @@ func (c *Context) GetSchemaByExpr(expr ast.Expr, contentType string) *spec.Schem
 	return NewSchemaBuilder(c, contentType).ParseExpr(expr)
 }
 
+func (c *Context) GetSchemaByType(t types.Type, contentType string) *spec.SchemaRef {
+	return NewSchemaBuilder(c, contentType).parseType(t)
+}
+
+// ResolveExpr 将绑定了字面量的变量 (及其字段) 替换为对应的字面量表达式. e.g.
+//
+//	r.Path => "/x" (r 绑定到 Route{Path: "/x"} 或者使用其初始化)
+func (c *Context) ResolveExpr(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return c.ResolveExpr(e.X)
+	case *ast.Ident:
+		if v, ok := c.Env.Lookup(e.Name).(*LiteralValue); ok {
+			return c.ResolveExpr(v.Expr)
+		}
+		if obj, ok := c.Package().TypesInfo.ObjectOf(e).(*types.Var); ok {
+			if value, ok := c.LookupVarValue(obj).(*ast.CompositeLit); ok {
+				return value
+			}
+		}
+	case *ast.SelectorExpr:
+		x := c.ResolveExpr(e.X)
+		if unary, ok := x.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			x = unary.X
+		}
+		if lit, ok := x.(*ast.CompositeLit); ok {
+			if field := c.compositeField(lit, e.Sel.Name); field != nil {
+				return c.ResolveExpr(field)
+			}
+		}
+	}
+	return expr
+}
+
+// compositeField 获取结构体字面量中字段的值
+func (c *Context) compositeField(lit *ast.CompositeLit, name string) ast.Expr {
+	var st *types.Struct
+	if t := c.Package().TypesInfo.TypeOf(lit); t != nil {
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		st, _ = t.Underlying().(*types.Struct)
+	}
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+				return kv.Value
+			}
+			continue
+		}
+		if st != nil && i < st.NumFields() && st.Field(i).Name() == name {
+			return elt
+		}
+	}
+	return nil
+}
+
+// LookupVarValue 获取当前包中变量的初始值表达式 (var 声明或者 := 赋值)
+func (c *Context) LookupVarValue(obj *types.Var) (value ast.Expr) {
+	if obj.Pkg() == nil || obj.Pkg().Path() != c.Package().PkgPath {
+		return nil
+	}
+	for _, file := range c.Package().Syntax {
+		if file.Pos() > obj.Pos() || obj.Pos() > file.End() {
+			continue
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			if value != nil {
+				return false
+			}
+			switch node := node.(type) {
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if c.Package().TypesInfo.Defs[name] == obj && i < len(node.Values) {
+						value = node.Values[i]
+					}
+				}
+			case *ast.AssignStmt:
+				if node.Tok != token.DEFINE || len(node.Lhs) != len(node.Rhs) {
+					return true
+				}
+				for i, lh := range node.Lhs {
+					if ident, ok := lh.(*ast.Ident); ok && c.Package().TypesInfo.Defs[ident] == obj {
+						value = node.Rhs[i]
+					}
+				}
+			}
+			return true
+		})
+	}
+	return
+}
+
+// EvalString 计算字符串表达式的值. 支持常量, 使用常量初始化的变量, 字符串拼接以及 fmt.Sprintf/path.Join 等简单的函数调用. e.g.
+//
+//	apiPrefix + "/users/:id"
+//	fmt.Sprintf("/v%d", version)
+func (c *Context) EvalString(expr ast.Expr) (string, bool) {
+	if ctx, arg := c.ResolveArg(expr); arg != expr {
+		return ctx.EvalString(arg)
+	}
+	value := c.evalConst(expr)
+	if value == nil || value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(value), true
+}
+
+func (c *Context) evalConst(expr ast.Expr) constant.Value {
+	expr = c.ResolveExpr(expr)
+	if tv, ok := c.Package().TypesInfo.Types[expr]; ok && tv.Value != nil {
+		return tv.Value
+	}
+
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		obj, ok := c.Package().TypesInfo.ObjectOf(expr).(*types.Var)
+		if !ok {
+			return nil
+		}
+		value := c.LookupVarValue(obj)
+		if value == nil {
+			return nil
+		}
+		return c.evalConst(value)
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			return nil
+		}
+		x, y := c.evalConst(expr.X), c.evalConst(expr.Y)
+		if x == nil || y == nil || x.Kind() != y.Kind() {
+			return nil
+		}
+		return constant.BinaryOp(x, token.ADD, y)
+	case *ast.CallExpr:
+		return c.evalCall(expr)
+	}
+	return nil
+}
+
+// evalCall 计算 fmt.Sprintf/path.Join/strconv.Itoa 调用的值
+func (c *Context) evalCall(call *ast.CallExpr) constant.Value {
+	fn := c.GetFuncFromAstNode(call.Fun)
+	if fn == nil || fn.Pkg() == nil {
+		return nil
+	}
+	name := fn.Pkg().Path() + "." + fn.Name()
+	if !lo.Contains([]string{"fmt.Sprintf", "path.Join", "strconv.Itoa"}, name) {
+		return nil
+	}
+	var args []interface{}
+	for _, arg := range call.Args {
+		value := c.evalConst(arg)
+		if value == nil {
+			return nil
+		}
+		args = append(args, constant.Val(value))
+	}
+
+	switch name {
+	case "fmt.Sprintf":
+		if len(args) == 0 {
+			return nil
+		}
+		format, ok := args[0].(string)
+		if !ok {
+			return nil
+		}
+		return constant.MakeString(fmt.Sprintf(format, args[1:]...))
+	case "path.Join":
+		var elem []string
+		for _, arg := range args {
+			s, ok := arg.(string)
+			if !ok {
+				return nil
+			}
+			elem = append(elem, s)
+		}
+		return constant.MakeString(path.Join(elem...))
+	case "strconv.Itoa":
+		if len(args) != 1 {
+			return nil
+		}
+		if i, ok := args[0].(int64); ok {
+			return constant.MakeString(strconv.FormatInt(i, 10))
+		}
+	}
+	return nil
+}
+
 func (c *Context) GetHeadingCommentOf(pos token.Pos) *ast.CommentGroup {
 	if c.File() == nil {
 		return nil
@@ -179,6 +474,18 @@ func (c *Context) NewEnv() *Context {
 	return &res
 }
 
+func (c *Context) withRoot(root *ast.FuncDecl) *Context {
+	res := *c
+	res.root = root
+	return &res
+}
+
+func (c *Context) withNode(node ast.Node) *Context {
+	res := *c
+	res.node = node
+	return &res
+}
+
 type CallRule struct {
 	Rules map[string][]string // typeName to function-names
 }
@@ -324,5 +631,9 @@ func (c *Context) parseCallInfoByIdent(ident *ast.Ident) (info *CallInfo) {
 }
 
 func (c *Context) ParseComment(commentGroup *ast.CommentGroup) *Comment {
-	return ParseComment(commentGroup, c.Package().Fset)
+	comment := ParseComment(commentGroup, c.Package().Fset)
+	if comment != nil {
+		c.analyzer.report(comment.Diagnostics...)
+	}
+	return comment
 }
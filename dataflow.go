@@ -0,0 +1,242 @@
+package eapi
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+
+	"github.com/gotomicro/eapi/spec"
+	"github.com/samber/lo"
+)
+
+// valueExpr 表达式及其所在的上下文. 通过 EnterFunc 绑定的实参与函数体位于不同的上下文
+type valueExpr struct {
+	ctx  *Context
+	expr ast.Expr
+}
+
+// fieldKey 用于记录已经追踪过的局部变量字段
+type fieldKey struct {
+	obj   *types.Var
+	field string
+}
+
+// concreteValues 追踪 interface 类型的表达式在函数内被赋予的具体类型的值. e.g.
+//
+//	var data any
+//	if x { data = listResp } else { data = itemResp }
+//	c.JSON(http.StatusOK, data) => listResp, itemResp
+//
+// 仅追踪局部变量以及局部变量的字段. 表达式本身不是 interface 类型时返回表达式自身
+func (c *Context) concreteValues(expr ast.Expr, visited map[interface{}]bool) (res []valueExpr) {
+	ctx, expr := c.ResolveArg(expr)
+	t := ctx.Package().TypesInfo.TypeOf(expr)
+	if t == nil || isUntypedNil(t) {
+		return nil
+	}
+	if !types.IsInterface(t) {
+		return []valueExpr{{ctx: ctx, expr: expr}}
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		obj := ctx.localVar(e)
+		if obj == nil || visited[obj] {
+			return nil
+		}
+		visited[obj] = true
+		for _, value := range ctx.assignedValues(obj) {
+			res = append(res, ctx.concreteValues(value, visited)...)
+		}
+	case *ast.SelectorExpr: // resp.Data
+		x, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		if obj := ctx.localVar(x); obj != nil {
+			res = ctx.fieldValues(obj, e.Sel.Name, visited)
+		}
+	}
+	return
+}
+
+// fieldValues 追踪局部变量 obj 的字段 field 被赋予的具体类型的值. 包括初始化时的结构体字面量以及 obj.field = value 形式的赋值
+func (c *Context) fieldValues(obj *types.Var, field string, visited map[interface{}]bool) (res []valueExpr) {
+	key := fieldKey{obj: obj, field: field}
+	if visited[key] {
+		return nil
+	}
+	visited[key] = true
+
+	for _, value := range c.assignedValues(obj) {
+		if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+			value = unary.X
+		}
+		if lit, ok := value.(*ast.CompositeLit); ok {
+			if fieldValue := c.compositeField(lit, field); fieldValue != nil {
+				res = append(res, c.concreteValues(fieldValue, visited)...)
+			}
+		}
+	}
+	c.inspectAssignments(obj, func(lhs, rhs ast.Expr) {
+		sel, ok := lhs.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != field {
+			return
+		}
+		if x, ok := sel.X.(*ast.Ident); ok && c.Package().TypesInfo.ObjectOf(x) == obj {
+			res = append(res, c.concreteValues(rhs, visited)...)
+		}
+	})
+	return
+}
+
+// assignedValues 获取局部变量 obj 的初始值以及之后被赋予的值
+func (c *Context) assignedValues(obj *types.Var) (values []ast.Expr) {
+	c.inspectAssignments(obj, func(lhs, rhs ast.Expr) {
+		if ident, ok := lhs.(*ast.Ident); ok && c.Package().TypesInfo.ObjectOf(ident) == obj {
+			values = append(values, rhs)
+		}
+	})
+	return
+}
+
+// inspectAssignments 遍历局部变量 obj 所在文件中的 var 声明和赋值语句
+func (c *Context) inspectAssignments(obj *types.Var, callback func(lhs, rhs ast.Expr)) {
+	for _, file := range c.Package().Syntax {
+		if file.Pos() > obj.Pos() || obj.Pos() > file.End() {
+			continue
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.ValueSpec:
+				for i, name := range node.Names {
+					if i < len(node.Values) {
+						callback(name, node.Values[i])
+					}
+				}
+			case *ast.AssignStmt:
+				if len(node.Lhs) != len(node.Rhs) {
+					return true
+				}
+				for i, lhs := range node.Lhs {
+					callback(lhs, node.Rhs[i])
+				}
+			}
+			return true
+		})
+	}
+}
+
+// localVar 获取标识符对应的函数内局部变量. 不是局部变量时返回 nil
+func (c *Context) localVar(ident *ast.Ident) *types.Var {
+	obj, ok := c.Package().TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || obj.IsField() || obj.Pkg() == nil || obj.Parent() == nil || obj.Parent() == obj.Pkg().Scope() {
+		return nil
+	}
+	return obj
+}
+
+func isUntypedNil(t types.Type) bool {
+	basic, ok := t.(*types.Basic)
+	return ok && basic.Kind() == types.UntypedNil
+}
+
+// parseConcreteValues 根据 interface 类型的表达式被赋予的具体值生成 schema. 存在多个不同的 schema 时生成 oneOf. 没有具体值时返回 nil
+func (s *SchemaBuilder) parseConcreteValues(values []valueExpr) *spec.SchemaRef {
+	var schemas []*spec.SchemaRef
+	for _, value := range values {
+		schema := newSchemaBuilderWithStack(value.ctx, s.contentType, s.stack).ParseExpr(value.expr)
+		if schema == nil {
+			continue
+		}
+		exists := lo.ContainsBy(schemas, func(item *spec.SchemaRef) bool { return reflect.DeepEqual(item, schema) })
+		if !exists {
+			schemas = append(schemas, schema)
+		}
+	}
+	switch len(schemas) {
+	case 0:
+		return nil
+	case 1:
+		return schemas[0]
+	}
+	return spec.NewOneOfSchema(schemas...)
+}
+
+// parseLocalVar 解析局部变量的类型. interface 类型的变量 (及结构体变量中 interface 类型的字段) 使用被赋予的具体值的类型
+func (s *SchemaBuilder) parseLocalVar(obj *types.Var, t types.Type) *spec.SchemaRef {
+	if types.IsInterface(t) {
+		var values []valueExpr
+		visited := map[interface{}]bool{obj: true}
+		for _, value := range s.ctx.assignedValues(obj) {
+			values = append(values, s.ctx.concreteValues(value, visited)...)
+		}
+		if schema := s.parseConcreteValues(values); schema != nil {
+			return schema
+		}
+		return s.parseType(t)
+	}
+	return s.specializeFields(s.parseType(t), t, func(name string) []valueExpr {
+		return s.ctx.fieldValues(obj, name, make(map[interface{}]bool))
+	})
+}
+
+// parseCompositeLit 解析结构体字面量. e.g.
+//
+//	Response{Data: data} => Response.data 的 schema 为 data 的类型 (data 为 helper 函数参数时, 为调用处实参的类型)
+func (s *SchemaBuilder) parseCompositeLit(expr *ast.CompositeLit) *spec.SchemaRef {
+	return s.specializeFields(s.ParseExpr(expr.Type), s.ctx.Package().TypesInfo.TypeOf(expr), func(name string) []valueExpr {
+		value := s.ctx.compositeField(expr, name)
+		if value == nil {
+			return nil
+		}
+		return s.ctx.concreteValues(value, make(map[interface{}]bool))
+	})
+}
+
+// specializeFields 使用 fieldValues 返回的具体值替换结构体 t 中 interface 类型字段的 schema. 字段被替换时返回新的内联 schema
+func (s *SchemaBuilder) specializeFields(schema *spec.SchemaRef, t types.Type, fieldValues func(name string) []valueExpr) *spec.SchemaRef {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	def, ok := s.ctx.ParseType(t).(*TypeDefinition)
+	if !ok {
+		return schema
+	}
+	st, ok := def.Spec.Type.(*ast.StructType)
+	if !ok {
+		return schema
+	}
+
+	contentType := s.contentType
+	if contentType == "" {
+		contentType = MimeTypeJson
+	}
+	builder := newSchemaBuilderWithStack(s.ctx.WithPackage(def.pkg).WithFile(def.file), s.contentType, s.stack).WithFieldNameParser(s.fieldNameParser)
+
+	var res *spec.Schema
+	for _, field := range st.Fields.List {
+		if !types.IsInterface(def.pkg.TypesInfo.TypeOf(field.Type)) {
+			continue
+		}
+		for _, name := range field.Names {
+			valueSchema := s.parseConcreteValues(fieldValues(name.Name))
+			if valueSchema == nil {
+				continue
+			}
+			if res == nil {
+				res = spec.Unref(s.ctx.Doc(), schema).Clone()
+			}
+			propName := builder.getPropName(name.Name, field, contentType)
+			if prop, ok := res.Properties[propName]; ok && prop.Description != "" && valueSchema.Ref == "" {
+				valueSchema.Description = prop.Description
+			}
+			res.Properties[propName] = valueSchema
+		}
+	}
+	if res == nil {
+		return schema
+	}
+	return res
+}
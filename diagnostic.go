@@ -0,0 +1,56 @@
+package eapi
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"strings"
+)
+
+// Diagnostic 解析注解时发现的问题, 比如语法错误或者未知的注解
+type Diagnostic struct {
+	Position token.Position
+	Message  string
+	// 未知注解的标签. 插件或者配置中声明的自定义注解不会被报告
+	unknownTag string
+}
+
+func (d *Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Position.String(), d.Message)
+}
+
+// report 记录并输出注解的问题. 同一处注释可能会被多次解析, 相同的问题只记录一次
+func (a *Analyzer) report(diagnostics ...*Diagnostic) {
+	for _, d := range diagnostics {
+		if _, ok := a.customAnnotations[strings.ToLower(d.unknownTag)]; ok {
+			continue
+		}
+		key := d.String()
+		if _, ok := a.reported[key]; ok {
+			continue
+		}
+		a.reported[key] = struct{}{}
+		a.diagnostics = append(a.diagnostics, d)
+		fmt.Fprintf(os.Stderr, "[Invalid Annotation]: %s\n", key)
+	}
+}
+
+// Annotations 声明自定义注解. e.g. "@audit". 自定义注解会原样保留在 Comment.Annotations 中供插件使用, 不会被报告为未知的注解
+func (a *Analyzer) Annotations(tags ...string) *Analyzer {
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if !strings.HasPrefix(tag, "@") {
+			tag = "@" + tag
+		}
+		a.customAnnotations[tag] = struct{}{}
+	}
+	return a
+}
+
+// Diagnostics 返回解析过程中发现的注解问题
+func (a *Analyzer) Diagnostics() []*Diagnostic {
+	return a.diagnostics
+}
@@ -21,6 +21,16 @@ type Config struct {
 	Output  string
 	Depends []string
 	OpenAPI OpenAPIConfig
+	// 类型映射. 用于指定类型对应的 schema
+	TypeMappings []*TypeMapping
+	// 示例文件的 glob 表达式
+	Examples string
+	// 自定义注解. 不会被报告为未知的注解
+	Annotations []string
+	// 存在注解错误时退出
+	Strict bool
+	// 生成生产环境的文档, 隐藏声明了 @hidden-in-production 的接口
+	Production bool
 
 	Generators []*GeneratorConfig
 }
@@ -127,6 +137,16 @@ func (e *Entrypoint) Run(args []string) {
 			return nil
 		},
 	})
+	app.Flags = append(app.Flags, &cli.BoolFlag{
+		Name:        "strict",
+		Usage:       "exit with error when there are invalid or unknown annotations",
+		Destination: &e.cfg.Strict,
+	})
+	app.Flags = append(app.Flags, &cli.BoolFlag{
+		Name:        "production",
+		Usage:       "generate documentation for production, operations marked with @hidden-in-production are excluded",
+		Destination: &e.cfg.Production,
+	})
 	app.Flags = append(app.Flags, &cli.StringFlag{
 		Name:     "config",
 		Aliases:  []string{"c"},
@@ -212,8 +232,12 @@ func (e *Entrypoint) run(c *cli.Context) error {
 		return err
 	}
 
-	a := NewAnalyzer(e.k).Plugin(plugin).Depends(e.cfg.Depends...)
-	doc := a.Process(e.cfg.Dir).Doc().Specialize()
+	a := NewAnalyzer(e.k).Plugin(plugin).Depends(e.cfg.Depends...).TypeMappings(e.cfg.TypeMappings...).Examples(e.cfg.Examples).Production(e.cfg.Production).Annotations(e.cfg.Annotations...)
+	a.Process(e.cfg.Dir)
+	if diagnostics := a.Diagnostics(); e.cfg.Strict && len(diagnostics) > 0 {
+		return fmt.Errorf("found %d annotation error(s)", len(diagnostics))
+	}
+	doc := a.Doc().Specialize()
 	e.cfg.OpenAPI.ApplyToDoc(doc)
 
 	// write documentation
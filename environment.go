@@ -1,6 +1,8 @@
 package eapi
 
 import (
+	"go/ast"
+
 	"github.com/gotomicro/eapi/annotation"
 	"github.com/samber/lo"
 )
@@ -47,6 +49,17 @@ func (e *Environment) Assign(k, v interface{}) *Environment {
 	return e
 }
 
+// LiteralValue 变量绑定的字面量表达式. e.g. 展开 range 循环时, value 变量绑定到每个元素
+type LiteralValue struct {
+	Expr ast.Expr
+}
+
+// ArgValue 函数参数绑定的实参表达式. ctx 为调用处的上下文
+type ArgValue struct {
+	Expr ast.Expr
+	ctx  *Context
+}
+
 type CommentStack struct {
 	parent  *CommentStack
 	comment *Comment
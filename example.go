@@ -0,0 +1,121 @@
+package eapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gotomicro/eapi/annotation"
+	"github.com/gotomicro/eapi/spec"
+)
+
+// addExample 为 content 中的每种 media type 添加示例. 示例名称重复时添加数字后缀
+func addExample(content spec.Content, name string, value interface{}) {
+	for _, mediaType := range content {
+		key := name
+		for i := 2; mediaType.Examples[key] != nil; i++ {
+			key = name + "_" + strconv.Itoa(i)
+		}
+		mediaType.WithExample(key, value)
+	}
+}
+
+// exampleContent 获取请求体或者指定状态码的响应的 content. target 为 request 或者状态码
+func (s *APISpec) exampleContent(target string) spec.Content {
+	if target == annotation.ExampleTargetRequest {
+		if s.RequestBody == nil {
+			return nil
+		}
+		return s.RequestBody.Content
+	}
+	if res := s.Responses[target]; res != nil {
+		return res.Content
+	}
+	return nil
+}
+
+// mergeCommentExamples 添加 handler 注释中通过 @example request/response 声明的示例
+func (s *APISpec) mergeCommentExamples() {
+	for _, annot := range s.commentExamples {
+		target := annot.Target
+		if target == annotation.ExampleTargetResponse {
+			target = annot.Status
+		}
+		content := s.exampleContent(target)
+		if len(content) == 0 {
+			fmt.Fprintf(os.Stderr, "@example of operation %s: %s body not found\n", s.OperationID, target)
+			continue
+		}
+		addExample(content, "example", annotationValue(annot.Value, ""))
+	}
+	s.commentExamples = nil
+}
+
+// Examples 设置示例文件. pattern 为 glob 表达式, 相对路径相对于解析的代码目录. 示例文件的格式为
+//
+//	{
+//	  "<operationId>": {
+//	    "request": {...},
+//	    "200": {...}
+//	  }
+//	}
+//
+// 文件名 (不包括扩展名) 作为示例的名称
+func (a *Analyzer) Examples(pattern string) *Analyzer {
+	a.examplesPattern = pattern
+	return a
+}
+
+func (a *Analyzer) loadExamples(dir string) {
+	if a.examplesPattern == "" {
+		return
+	}
+	pattern := a.examplesPattern
+	if !filepath.IsAbs(pattern) {
+		pattern = filepath.Join(dir, pattern)
+	}
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid examples pattern '%s': %s\n", a.examplesPattern, err.Error())
+		return
+	}
+
+	apis := make(map[string][]*APISpec)
+	for _, api := range a.routes {
+		apis[api.Spec.OperationID] = append(apis[api.Spec.OperationID], api.Spec)
+	}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "read examples file %s failed: %s\n", file, err.Error())
+			continue
+		}
+		var fixtures map[string]map[string]interface{}
+		if err := json.Unmarshal(content, &fixtures); err != nil {
+			fmt.Fprintf(os.Stderr, "invalid examples file %s: %s\n", file, err.Error())
+			continue
+		}
+
+		name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		for operationID, examples := range fixtures {
+			specs, ok := apis[operationID]
+			if !ok {
+				fmt.Fprintf(os.Stderr, "examples file %s: operation %s not found\n", file, operationID)
+				continue
+			}
+			for target, value := range examples {
+				for _, s := range specs {
+					content := s.exampleContent(strings.ToLower(target))
+					if len(content) == 0 {
+						fmt.Fprintf(os.Stderr, "examples file %s: %s body of operation %s not found\n", file, target, operationID)
+						continue
+					}
+					addExample(content, name, value)
+				}
+			}
+		}
+	}
+}
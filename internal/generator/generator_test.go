@@ -66,6 +66,33 @@ function hello() {
 }`,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "print-oneOf-union",
+			args: args{
+				jsCode: `
+const { printDocToString, TsPrinter } = require("eapi");
+function print(doc) {
+	const printer = new TsPrinter(doc);
+	return [{code: printDocToString(printer.typeDef(doc.components.schemas.Shape), { tabWidth: 2 }).formatted}];
+}
+module.exports = { print }
+`,
+				doc: &spec.T{
+					Components: spec.Components{
+						Schemas: spec.Schemas{
+							"Shape": &spec.Schema{
+								Title: "Shape",
+								OneOf: spec.SchemaRefs{spec.RefComponentSchemas("Circle"), spec.RefComponentSchemas("Rect")},
+							},
+							"Circle": &spec.Schema{Title: "Circle", Type: spec.TypeObject},
+							"Rect":   &spec.Schema{Title: "Rect", Type: spec.TypeObject},
+						},
+					},
+				},
+			},
+			wantResult: `export type Shape = Circle | Rect`,
+			wantErr:    assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -88,6 +88,10 @@ class Printer {
       return typeName || 'unknown';
     }
 
+    if (schema.oneOf?.length) {
+      return join(' | ', schema.oneOf.map(s => this.typeName(s)))
+    }
+
     const ext = schema.ext;
     switch (ext?.type) {
       case "any":
@@ -0,0 +1,152 @@
+package eapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/gotomicro/eapi/spec"
+)
+
+// discriminatorCandidates 未声明 @discriminator 时, 所有实现类型都包含的以下属性会被作为 discriminator
+var discriminatorCandidates = []string{"kind", "type"}
+
+// parseInterfaceType 将 interface 类型转换为其实现类型的 oneOf. e.g.
+//
+//	// @oneOf Circle Rect
+//	// @discriminator kind
+//	type Shape interface { Area() float64 }
+//
+// 未声明 @oneOf 时, 使用已加载的包中实现了该接口的类型. 没有方法的接口或者找不到实现类型时返回 nil
+func (s *SchemaBuilder) parseInterfaceType(t *ast.TypeSpec, comment *Comment) *spec.SchemaRef {
+	obj, ok := s.ctx.Package().TypesInfo.Defs[t.Name].(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+
+	var impls []*types.TypeName
+	if names := comment.OneOf(); len(names) > 0 {
+		impls = s.lookupTypeNames(names, t.Pos())
+	} else if iface.NumMethods() > 0 && iface.IsMethodSet() {
+		impls = s.ctx.implementationsOf(iface)
+	}
+	if len(impls) == 0 {
+		return nil
+	}
+
+	var schemas []*spec.SchemaRef
+	for _, impl := range impls {
+		schemas = append(schemas, s.parseType(impl.Type()))
+	}
+	schema := spec.NewOneOfSchema(schemas...)
+	schema.Discriminator = s.discriminator(comment.Discriminator(), impls, schemas)
+	return schema
+}
+
+// lookupTypeNames 查找 @oneOf 中声明的类型. 类型名可以是当前包中的类型, 或者使用 "包名.类型名" 引用其他包中的类型
+func (s *SchemaBuilder) lookupTypeNames(names []string, pos token.Pos) (res []*types.TypeName) {
+	pkg := s.ctx.Package().Types
+	for _, name := range names {
+		scope := pkg.Scope()
+		pkgName, typeName, qualified := strings.Cut(name, ".")
+		if qualified {
+			scope = nil
+			for _, imported := range pkg.Imports() {
+				if imported.Name() == pkgName {
+					scope = imported.Scope()
+					break
+				}
+			}
+		} else {
+			typeName = pkgName
+		}
+		var obj *types.TypeName
+		if scope != nil {
+			obj, _ = scope.Lookup(typeName).(*types.TypeName)
+		}
+		if obj == nil {
+			s.ctx.analyzer.report(&Diagnostic{
+				Position: s.ctx.Package().Fset.Position(pos),
+				Message:  fmt.Sprintf("unknown type '%s' in @oneOf", name),
+			})
+			continue
+		}
+		res = append(res, obj)
+	}
+	return
+}
+
+// implementationsOf 查找已加载的包中实现了接口 iface 的类型 (包括指针接收者实现的类型). 泛型类型会被忽略
+func (c *Context) implementationsOf(iface *types.Interface) (res []*types.TypeName) {
+	keys := make([]string, 0, len(c.analyzer.definitions))
+	for key := range c.analyzer.definitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		def, ok := c.analyzer.definitions[key].(*TypeDefinition)
+		if !ok || def.Spec.TypeParams != nil {
+			continue
+		}
+		obj, ok := def.pkg.TypesInfo.Defs[def.Spec.Name].(*types.TypeName)
+		if !ok || obj.IsAlias() || types.IsInterface(obj.Type()) {
+			continue
+		}
+		if types.Implements(obj.Type(), iface) || types.Implements(types.NewPointer(obj.Type()), iface) {
+			res = append(res, obj)
+		}
+	}
+	return
+}
+
+// discriminator 生成 oneOf 的 discriminator. 实现类型的 discriminator 属性只有一个枚举值时 (e.g. `binding:"oneof=circle"`)
+// 使用该值作为 mapping 的 key, 否则使用类型名
+func (s *SchemaBuilder) discriminator(propertyName string, impls []*types.TypeName, schemas []*spec.SchemaRef) *spec.Discriminator {
+	properties := make([]*spec.Schema, len(schemas))
+	lookup := func(name string) (ok bool) {
+		ok = true
+		for i, schema := range schemas {
+			properties[i] = nil
+			if impl := spec.Unref(s.ctx.Doc(), schema); impl != nil {
+				properties[i] = impl.Properties[name]
+			}
+			ok = ok && properties[i] != nil
+		}
+		return
+	}
+
+	if propertyName == "" {
+		for _, candidate := range discriminatorCandidates {
+			if lookup(candidate) {
+				propertyName = candidate
+				break
+			}
+		}
+		if propertyName == "" {
+			return nil
+		}
+	} else {
+		lookup(propertyName)
+	}
+
+	mapping := make(map[string]string)
+	for i, schema := range schemas {
+		if schema.Ref == "" {
+			continue
+		}
+		value := impls[i].Name()
+		if property := properties[i]; property != nil && len(property.Enum) == 1 {
+			value = fmt.Sprint(property.Enum[0])
+		}
+		mapping[value] = schema.Ref
+	}
+	return &spec.Discriminator{PropertyName: propertyName, Mapping: mapping}
+}
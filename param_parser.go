@@ -35,6 +35,11 @@ func (p *ParamParser) Parse(expr ast.Expr) (params []*spec.Parameter) {
 	return
 }
 
+// ParseType 根据 types.Type 解析出 []*spec.Parameter
+func (p *ParamParser) ParseType(t types.Type) (params []*spec.Parameter) {
+	return p.parseType(t)
+}
+
 func (p *ParamParser) parseIdent(expr *ast.Ident) (params []*spec.Parameter) {
 	t := p.ctx.Package().TypesInfo.TypeOf(expr)
 	if t == nil {
@@ -66,6 +71,9 @@ func (p *ParamParser) parseStructType(structType *ast.StructType) (params []*spe
 		}
 
 		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
 			param := p.parseField(name, field)
 			params = append(params, param)
 		}
@@ -78,8 +86,11 @@ func (p *ParamParser) parseField(name *ast.Ident, field *ast.Field) (param *spec
 
 	param.Name = name.Name
 	param.In = "query"
-	if field.Tag != nil && p.nameParser != nil {
-		tagValues := tag.Parse(field.Tag.Value)
+	if p.nameParser != nil {
+		var tagValues map[string]string
+		if field.Tag != nil {
+			tagValues = tag.Parse(field.Tag.Value)
+		}
 		param.Name, param.In = p.nameParser(name.Name, tagValues)
 	}
 
@@ -90,6 +101,16 @@ func (p *ParamParser) parseField(name *ast.Ident, field *ast.Field) (param *spec
 		param.Description = comments.Text()
 		param.Deprecated = comments.Deprecated()
 	}
+	if field.Tag != nil {
+		tags := tag.Parse(field.Tag.Value)
+		applyValidationTags(param.Schema, tags)
+		if tag.Required(tags) {
+			param.Required = true
+		}
+	}
+	if param.In == "path" { // path 参数总是必需的
+		param.Required = true
+	}
 
 	return
 }
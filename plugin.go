@@ -12,3 +12,8 @@ type Plugin interface {
 	Mount(k *koanf.Koanf) error
 	Analyze(ctx *Context, node ast.Node)
 }
+
+// AnnotationPlugin 插件可以实现该接口, 声明插件使用的自定义注解. e.g. "@audit". 这些注解不会被报告为未知的注解
+type AnnotationPlugin interface {
+	Annotations() []string
+}
@@ -0,0 +1,369 @@
+package chi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/plugins/stdhttp"
+	"github.com/gotomicro/eapi/utils"
+	"github.com/knadh/koanf"
+	"github.com/samber/lo"
+)
+
+var (
+	routeMethods = []string{"Get", "Head", "Post", "Put", "Patch", "Delete", "Connect", "Options", "Trace"}
+	// 不限制请求方法的路由声明
+	anyMethods = []string{"Handle", "HandleFunc"}
+	// 在参数中指定请求方法的路由声明
+	customMethods = []string{"Method", "MethodFunc"}
+
+	allRouteMethods = lo.Flatten([][]string{routeMethods, anyMethods, customMethods})
+)
+
+const (
+	chiPackageName    = "github.com/go-chi/chi/v5"
+	chiMuxTypeName    = "*github.com/go-chi/chi/v5.Mux"
+	chiRouterTypeName = "github.com/go-chi/chi/v5.Router"
+
+	withMethodName  = "With"
+	groupMethodName = "Group"
+	routeMethodName = "Route"
+	mountMethodName = "Mount"
+)
+
+// mountPrefix 作为 Environment 的 key, 记录 r.Mount() 挂载的子路由所在的路由分组
+type mountPrefix struct{}
+
+var _ eapi.Plugin = &Plugin{}
+
+type Plugin struct {
+	config common.Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Name() string {
+	return "chi"
+}
+
+func (p *Plugin) Mount(k *koanf.Koanf) error {
+	return k.Unmarshal("properties", &p.config)
+}
+
+func (p *Plugin) Analyze(ctx *eapi.Context, node ast.Node) {
+	switch node := node.(type) {
+	case *ast.AssignStmt:
+		p.assignStmt(ctx, node)
+	case *ast.CallExpr:
+		p.callExpr(ctx, node)
+	}
+}
+
+func (p *Plugin) routerRule(methods ...string) *eapi.CallRule {
+	callRule := eapi.NewCallRule().
+		WithRule(chiMuxTypeName, methods...).
+		WithRule(chiRouterTypeName, methods...)
+	for _, router := range p.config.RouterNames {
+		callRule = callRule.WithRule(router, methods...)
+	}
+	return callRule
+}
+
+// assignStmt 记录变量对应的路由分组. e.g.
+//
+//	r := chi.NewRouter()
+//	api := r.Route("/api", func(r chi.Router) {})
+//	admin := r.With(AdminOnly)
+func (p *Plugin) assignStmt(ctx *eapi.Context, assign *ast.AssignStmt) {
+	if len(assign.Rhs) != 1 || len(assign.Lhs) != 1 {
+		return
+	}
+	lhIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+
+	var rg *eapi.RouteGroup
+	rh := assign.Rhs[0]
+	ctx.MatchCall(
+		rh,
+		eapi.NewCallRule().WithRule(chiPackageName, "NewRouter", "NewMux"),
+		func(call *ast.CallExpr, typeName, fnName string) {
+			rg = &eapi.RouteGroup{}
+			if prefix, ok := ctx.Env.Lookup(mountPrefix{}).(string); ok {
+				rg.Prefix = prefix
+			}
+		},
+	)
+	ctx.MatchCall(
+		rh,
+		p.routerRule(withMethodName, groupMethodName, routeMethodName),
+		func(call *ast.CallExpr, typeName, fnName string) {
+			rg = p.routeGroupOf(ctx, call)
+		},
+	)
+	if rg == nil {
+		return
+	}
+
+	obj := ctx.Package().TypesInfo.ObjectOf(lhIdent)
+	if obj == nil {
+		return
+	}
+	switch assign.Tok {
+	case token.ASSIGN:
+		env := ctx.Env.Resolve(obj)
+		if env == nil {
+			ctx.Env.Define(obj, rg)
+		} else {
+			env.Assign(obj, rg)
+		}
+	case token.DEFINE:
+		ctx.Env.Define(obj, rg)
+	}
+}
+
+func (p *Plugin) callExpr(ctx *eapi.Context, callExpr *ast.CallExpr) {
+	ctx.MatchCall(
+		callExpr,
+		p.routerRule(allRouteMethods...),
+		func(call *ast.CallExpr, typeName, fnName string) {
+			comment := ctx.ParseComment(ctx.GetHeadingCommentOf(call.Pos()))
+			if comment.Ignore() {
+				return
+			}
+			api := p.parseAPI(ctx, call, fnName, comment)
+			if api == nil {
+				return
+			}
+			ctx.AddAPI(api)
+		},
+	)
+
+	ctx.MatchCall(
+		callExpr,
+		p.routerRule(groupMethodName, routeMethodName, mountMethodName),
+		func(call *ast.CallExpr, typeName, fnName string) {
+			selExpr := call.Fun.(*ast.SelectorExpr)
+			switch fnName {
+			case groupMethodName: // r.Group(func(r chi.Router) {...})
+				if len(call.Args) != 1 {
+					return
+				}
+				p.bindRouterFunc(ctx, call.Args[0], p.routeGroupOf(ctx, selExpr.X))
+			case routeMethodName: // r.Route("/prefix", func(r chi.Router) {...})
+				if len(call.Args) != 2 {
+					return
+				}
+				p.bindRouterFunc(ctx, call.Args[1], p.routeGroupOf(ctx, call))
+			case mountMethodName: // r.Mount("/prefix", subRouter())
+				if len(call.Args) != 2 {
+					return
+				}
+				p.mount(ctx, call)
+			}
+		},
+	)
+}
+
+// bindRouterFunc 将 func(r chi.Router) 的参数 r 绑定到路由分组 rg
+func (p *Plugin) bindRouterFunc(ctx *eapi.Context, fn ast.Expr, rg *eapi.RouteGroup) {
+	if rg == nil {
+		rg = &eapi.RouteGroup{}
+	}
+
+	switch fn := fn.(type) {
+	case *ast.FuncLit:
+		// 函数体会在后续的常规解析中被解析
+		param := p.firstParam(fn.Type)
+		if param == nil {
+			return
+		}
+		obj := ctx.Package().TypesInfo.ObjectOf(param)
+		if obj != nil {
+			ctx.Env.Define(obj, rg)
+		}
+	default:
+		def := ctx.GetFuncDefinition(fn)
+		if def == nil {
+			return
+		}
+		param := p.firstParam(def.Decl.Type)
+		if param == nil {
+			return
+		}
+		obj := def.Pkg().TypesInfo.ObjectOf(param)
+		ctx.AnalyzeFunc(def, func(env *eapi.Environment) {
+			env.Define(obj, rg)
+		})
+	}
+}
+
+// mount 解析 r.Mount("/prefix", subRouter()) . 在 subRouter 函数中创建的路由都会被挂载在 "/prefix" 下面
+func (p *Plugin) mount(ctx *eapi.Context, call *ast.CallExpr) {
+	mountPath, ok := ctx.EvalString(call.Args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unable to resolve mount path at %s\n", ctx.LineColumn(call.Args[0].Pos()))
+		return
+	}
+	handler, ok := call.Args[1].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	def := ctx.GetFuncDefinition(handler)
+	if def == nil {
+		return
+	}
+
+	var prefix string
+	if rg := p.routeGroupOf(ctx, call.Fun.(*ast.SelectorExpr).X); rg != nil {
+		prefix = rg.Prefix
+	}
+	prefix = path.Join(prefix, p.normalizePath(mountPath))
+	ctx.AnalyzeFunc(def, func(env *eapi.Environment) {
+		env.Define(mountPrefix{}, prefix)
+	})
+}
+
+func (p *Plugin) firstParam(fnType *ast.FuncType) *ast.Ident {
+	if fnType.Params == nil || len(fnType.Params.List) == 0 || len(fnType.Params.List[0].Names) == 0 {
+		return nil
+	}
+	return fnType.Params.List[0].Names[0]
+}
+
+// routeGroupOf 获取表达式对应的路由分组. 未知的路由分组返回 nil
+func (p *Plugin) routeGroupOf(ctx *eapi.Context, expr ast.Expr) *eapi.RouteGroup {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return p.routeGroupOf(ctx, expr.X)
+	case *ast.Ident:
+		obj := ctx.Package().TypesInfo.ObjectOf(expr)
+		if obj == nil {
+			return nil
+		}
+		rg, _ := ctx.Env.Lookup(obj).(*eapi.RouteGroup)
+		return rg
+	case *ast.SelectorExpr:
+		obj := ctx.Package().TypesInfo.ObjectOf(expr.Sel)
+		if obj == nil {
+			return nil
+		}
+		rg, _ := ctx.Env.Lookup(obj).(*eapi.RouteGroup)
+		return rg
+	case *ast.CallExpr:
+		var res *eapi.RouteGroup
+		ctx.MatchCall(
+			expr,
+			p.routerRule(withMethodName, groupMethodName, routeMethodName),
+			func(call *ast.CallExpr, typeName, fnName string) {
+				parent := p.routeGroupOf(ctx, call.Fun.(*ast.SelectorExpr).X)
+				if fnName != routeMethodName {
+					res = parent
+					return
+				}
+				if len(call.Args) == 0 {
+					return
+				}
+				groupPath, ok := ctx.EvalString(call.Args[0])
+				if !ok {
+					fmt.Fprintf(os.Stderr, "unable to resolve route group path at %s\n", ctx.LineColumn(call.Args[0].Pos()))
+					return
+				}
+				var prefix string
+				if parent != nil {
+					prefix = parent.Prefix
+				}
+				res = &eapi.RouteGroup{Prefix: path.Join(prefix, p.normalizePath(groupPath))}
+			},
+		)
+		return res
+	}
+	return nil
+}
+
+func (p *Plugin) parseAPI(ctx *eapi.Context, callExpr *ast.CallExpr, fnName string, comment *eapi.Comment) (api *eapi.API) {
+	args := callExpr.Args
+	method := strings.ToUpper(fnName)
+	switch {
+	case lo.Contains(customMethods, fnName):
+		if len(args) < 3 {
+			return
+		}
+		m, ok := ctx.EvalString(args[0])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unable to resolve route method at %s\n", ctx.LineColumn(args[0].Pos()))
+			return
+		}
+		method = strings.ToUpper(m)
+		args = args[1:]
+	case lo.Contains(anyMethods, fnName):
+		method = http.MethodGet
+	}
+	if len(args) < 2 {
+		return
+	}
+	routePath, ok := ctx.EvalString(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unable to resolve route path at %s\n", ctx.LineColumn(args[0].Pos()))
+		return
+	}
+
+	var prefix string
+	if rg := p.routeGroupOf(ctx, callExpr.Fun.(*ast.SelectorExpr).X); rg != nil {
+		prefix = rg.Prefix
+	}
+
+	handlerFn := stdhttp.ResolveHandlerFunc(ctx, args[len(args)-1])
+	if handlerFn == nil {
+		return
+	}
+	typeName, methodName := utils.GetFuncInfo(handlerFn)
+	handlerDef := ctx.GetDefinition(typeName, methodName)
+	if handlerDef == nil {
+		fmt.Fprintf(os.Stderr, "handler function %s.%s not found\n", typeName, methodName)
+		return
+	}
+	handlerFnDef, ok := handlerDef.(*eapi.FuncDefinition)
+	if !ok {
+		return
+	}
+
+	fullPath := path.Join("/", prefix, p.normalizePath(routePath))
+	api = eapi.NewAPI(method, fullPath)
+	api.Spec.LoadFromComment(ctx, comment)
+	api.Spec.LoadFromFuncDecl(ctx, handlerFnDef.Decl)
+	if api.Spec.OperationID == "" {
+		id := comment.ID()
+		if id == "" {
+			id = stdhttp.HandlerOperationID(handlerFnDef)
+		}
+		api.Spec.OperationID = id
+	}
+	newHandlerAnalyzer(
+		ctx.NewEnv().WithPackage(handlerFnDef.Pkg()).WithFile(handlerFnDef.File()),
+		api,
+		handlerFnDef.Decl,
+	).WithConfig(&p.config).Parse()
+
+	return
+}
+
+var (
+	// {id:[0-9]+} => {id}
+	pathParamPattern = regexp.MustCompile(`\{([^{}:]+):[^{}]+\}`)
+)
+
+func (p *Plugin) normalizePath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
@@ -0,0 +1,64 @@
+package chi
+
+import (
+	"go/ast"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/plugins/stdhttp"
+	"github.com/gotomicro/eapi/spec"
+)
+
+// chi 的 handler 就是 net/http 的 handler, 在 stdhttp 的基础上额外支持 chi.URLParam()
+type handlerAnalyzer struct {
+	ctx  *eapi.Context
+	api  *eapi.API
+	spec *eapi.APISpec
+	decl *ast.FuncDecl
+
+	c *common.Config
+}
+
+func newHandlerAnalyzer(ctx *eapi.Context, api *eapi.API, decl *ast.FuncDecl) *handlerAnalyzer {
+	return &handlerAnalyzer{ctx: ctx, api: api, spec: api.Spec, decl: decl}
+}
+
+func (p *handlerAnalyzer) WithConfig(c *common.Config) *handlerAnalyzer {
+	p.c = c
+	return p
+}
+
+func (p *handlerAnalyzer) Parse() {
+	stdhttp.NewHandlerAnalyzer(p.ctx, p.api, p.decl).WithConfig(p.c).Parse()
+
+	ast.Inspect(p.decl, func(node ast.Node) bool {
+		p.ctx.MatchCall(node,
+			eapi.NewCallRule().WithRule(chiPackageName, "URLParam", "URLParamFromCtx"),
+			func(call *ast.CallExpr, typeName, fnName string) {
+				p.parseURLParam(call)
+			},
+		)
+		return true
+	})
+}
+
+// parseURLParam 解析 chi.URLParam(r, "id") / chi.URLParamFromCtx(ctx, "id")
+func (p *handlerAnalyzer) parseURLParam(call *ast.CallExpr) {
+	if len(call.Args) != 2 {
+		return
+	}
+	arg1Lit, ok := call.Args[1].(*ast.BasicLit)
+	if !ok {
+		return
+	}
+	name := strings.Trim(arg1Lit.Value, "\"")
+	paramSchema := spec.NewSchema()
+	paramSchema.Title = name
+	paramSchema.Type = "string"
+
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	param := spec.NewPathParameter(name).WithSchema(paramSchema)
+	param.Description = comment.Text()
+	p.spec.AddParameter(param)
+}
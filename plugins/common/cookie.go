@@ -0,0 +1,76 @@
+package common
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	analyzer "github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/spec"
+	"github.com/samber/lo"
+)
+
+const setCookieHeaderName = "Set-Cookie"
+
+// MatchRequestCookie 匹配直接读取请求 cookie 的调用. e.g. r.Cookie("session") / c.Request.Cookie("session")
+func MatchRequestCookie(ctx *analyzer.Context, node ast.Node, callback func(call *ast.CallExpr)) {
+	ctx.MatchCall(node, analyzer.NewCallRule().WithRule(httpRequestTypeName, "Cookie"), func(call *ast.CallExpr, typeName, fnName string) {
+		callback(call)
+	})
+}
+
+// CookieName 解析 cookie 结构体中的 cookie 名称. e.g.
+//
+//	&http.Cookie{Name: "session"}
+//	cookie := &http.Cookie{Name: "session"}; c.SetCookie(cookie)
+func CookieName(ctx *analyzer.Context, expr ast.Expr) (string, bool) {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if v, ok := ctx.Package().TypesInfo.ObjectOf(ident).(*types.Var); ok {
+			if value := ctx.LookupVarValue(v); value != nil {
+				expr = value
+			}
+		}
+	}
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return "", false
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Name" {
+			return ctx.EvalString(kv.Value)
+		}
+	}
+	return "", false
+}
+
+// AddSetCookieHeader 为成功 (以及重定向) 响应添加 Set-Cookie 响应头. names 为 handler 中设置的 cookie 名称
+func AddSetCookieHeader(s *analyzer.APISpec, names []string) {
+	names = lo.Uniq(names)
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	for code, res := range s.Responses {
+		if status, err := strconv.Atoi(code); err == nil && status >= 400 {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(spec.Headers)
+		}
+		res.Headers[setCookieHeaderName] = &spec.HeaderRef{Value: &spec.Header{Parameter: spec.Parameter{
+			Description: "Cookies: " + strings.Join(names, ", "),
+			Schema:      spec.NewStringSchema(),
+		}}}
+	}
+}
@@ -0,0 +1,161 @@
+package common
+
+import (
+	"go/ast"
+	"go/types"
+	"net/http"
+
+	analyzer "github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/spec"
+)
+
+// Handler 路由声明中 handler 参数的解析结果
+type Handler struct {
+	// handler 函数. 对于 adapter 函数, 为被包装的业务函数
+	Fn *types.Func
+	// adapter 函数的请求/响应类型. 均为 nil 时 Fn 是需要解析函数体的 handler 函数
+	Request  types.Type
+	Response types.Type
+}
+
+// IsAdapter 是否是通过 adapter 函数生成的 handler. e.g. Handle[Req, Res](svc.Method)
+func (h *Handler) IsAdapter() bool {
+	return h.Request != nil || h.Response != nil
+}
+
+// ParseAdapter 根据 adapter 函数的请求/响应类型解析 API. nameParser 用于解析 GET 等请求中的 query 参数
+func (h *Handler) ParseAdapter(ctx *analyzer.Context, api *analyzer.API, nameParser analyzer.ParamNameParser) {
+	if h.Request != nil {
+		switch api.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+			params := analyzer.NewParamParser(ctx, nameParser).ParseType(h.Request)
+			for _, param := range params {
+				api.Spec.AddParameter(param)
+			}
+		default:
+			contentType := analyzer.MimeTypeJson
+			if len(api.Spec.Consumes) > 0 {
+				contentType = api.Spec.Consumes[0]
+			}
+			schema := ctx.GetSchemaByType(h.Request, contentType)
+			if schema != nil {
+				api.Spec.RequestBody = spec.NewRequestBody().WithSchemaRef(schema, []string{contentType})
+			}
+		}
+	}
+	if h.Response != nil {
+		res := spec.NewResponse()
+		schema := ctx.GetSchemaByType(h.Response, analyzer.MimeTypeJson)
+		res.Content = spec.NewContentWithSchemaRef(schema, []string{analyzer.MimeTypeJson})
+		api.Spec.AddResponse(http.StatusOK, res)
+	}
+}
+
+// ResolveHandler 解析路由声明中的 handler 参数, 支持任意层级的 wrapper/adapter 函数. e.g.
+//
+//	r.GET("/x", wrap(auth(h.Get)))
+//	r.GET("/x", Handle[Req, Res](svc.Method))
+//
+// isHandler 用于判断函数是否是框架的 handler 函数. 参数中没有框架的 handler 函数时, 根据 adapter 函数的泛型参数或者被包装函数的签名推导请求/响应类型
+func ResolveHandler(ctx *analyzer.Context, expr ast.Expr, isHandler func(sig *types.Signature) bool) *Handler {
+	if paren, ok := expr.(*ast.ParenExpr); ok {
+		return ResolveHandler(ctx, paren.X, isHandler)
+	}
+	if call, ok := expr.(*ast.CallExpr); ok {
+		h, _ := resolveCall(ctx, call, isHandler)
+		return h
+	}
+	fn := ctx.GetFuncFromAstNode(expr)
+	if fn == nil {
+		return nil
+	}
+	return &Handler{Fn: fn}
+}
+
+// resolveCall 优先使用参数中 (任意层级) 的框架 handler 函数, 其次是最内层的 adapter 函数
+func resolveCall(ctx *analyzer.Context, call *ast.CallExpr, isHandler func(sig *types.Signature) bool) (h *Handler, framework bool) {
+	for _, arg := range call.Args {
+		if paren, ok := arg.(*ast.ParenExpr); ok {
+			arg = paren.X
+		}
+		var res *Handler
+		if nested, ok := arg.(*ast.CallExpr); ok {
+			res, framework = resolveCall(ctx, nested, isHandler)
+		} else if fn := ctx.GetFuncFromAstNode(arg); fn != nil && isHandler(fn.Type().(*types.Signature)) {
+			res, framework = &Handler{Fn: fn}, true
+		}
+		if framework {
+			return res, true
+		}
+		if h == nil {
+			h = res
+		}
+	}
+	if h != nil {
+		return h, false
+	}
+	return resolveAdapter(ctx, call), false
+}
+
+// resolveAdapter 解析 adapter 函数. 泛型参数多于一个时, 第一个为请求类型, 最后一个为响应类型.
+// 否则使用被包装函数的签名: 最后一个非 context.Context 参数为请求类型, 第一个非 error 返回值为响应类型
+func resolveAdapter(ctx *analyzer.Context, call *ast.CallExpr) *Handler {
+	var target *types.Func
+	for _, arg := range call.Args {
+		if target = ctx.GetFuncFromAstNode(arg); target != nil {
+			break
+		}
+	}
+	if target == nil {
+		return nil
+	}
+
+	h := &Handler{Fn: target}
+	if typeArgs := typeArgsOf(ctx, call.Fun); typeArgs != nil && typeArgs.Len() > 1 {
+		h.Request, h.Response = typeArgs.At(0), typeArgs.At(typeArgs.Len()-1)
+		return h
+	}
+
+	sig := target.Type().(*types.Signature)
+	for i := 0; i < sig.Results().Len(); i++ {
+		if t := sig.Results().At(i).Type(); t.String() != "error" {
+			h.Response = t
+			break
+		}
+	}
+	// 没有返回值的函数 (e.g. func(c *CustomContext)) 需要解析函数体
+	if h.Response == nil {
+		return h
+	}
+	for i := sig.Params().Len() - 1; i >= 0; i-- {
+		if t := sig.Params().At(i).Type(); t.String() != "context.Context" {
+			h.Request = t
+			break
+		}
+	}
+	return h
+}
+
+// typeArgsOf 获取泛型函数调用的类型参数 (包括推导出的类型参数)
+func typeArgsOf(ctx *analyzer.Context, fun ast.Expr) *types.TypeList {
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		ident = f
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	default:
+		return nil
+	}
+	instance, ok := ctx.Package().TypesInfo.Instances[ident]
+	if !ok {
+		return nil
+	}
+	return instance.TypeArgs
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"go/ast"
+	"strings"
+
+	analyzer "github.com/gotomicro/eapi"
+)
+
+const (
+	httpHeaderTypeName  = "net/http.Header"
+	httpRequestTypeName = "*net/http.Request"
+)
+
+// HeaderNameParser 根据 `header` tag 解析 header 参数名. 用于 gin ShouldBindHeader / echo BindHeaders 等 header 绑定
+func HeaderNameParser(fieldName string, tags map[string]string) (name, in string) {
+	name, ok := tags["header"]
+	if ok {
+		name, _, _ = strings.Cut(name, ",")
+		return name, "header"
+	}
+	return fieldName, "header"
+}
+
+// MatchRequestHeaderGet 匹配直接读取请求 header 的调用. e.g.
+//
+//	c.Request.Header.Get("X-Request-ID")   // gin
+//	c.Request().Header.Get("X-Tenant-ID")  // echo
+func MatchRequestHeaderGet(ctx *analyzer.Context, node ast.Node, callback func(call *ast.CallExpr)) {
+	ctx.MatchCall(node, analyzer.NewCallRule().WithRule(httpHeaderTypeName, "Get", "Values"), func(call *ast.CallExpr, typeName, fnName string) {
+		header, ok := call.Fun.(*ast.SelectorExpr).X.(*ast.SelectorExpr)
+		if !ok || header.Sel.Name != "Header" {
+			return
+		}
+		t := ctx.Package().TypesInfo.TypeOf(header.X)
+		if t == nil || t.String() != httpRequestTypeName {
+			return
+		}
+		callback(call)
+	})
+}
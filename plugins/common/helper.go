@@ -0,0 +1,35 @@
+package common
+
+import (
+	"go/ast"
+
+	analyzer "github.com/gotomicro/eapi"
+	"github.com/samber/lo"
+)
+
+// MatchHelperCall 匹配将框架的请求上下文 (e.g. *gin.Context) 作为参数传递给其他函数的调用. e.g.
+//
+//	resp.OK(c, data)
+//	render.Error(c, err)
+//
+// callback 中的 ctx 用于解析被调用函数的函数体, 其中函数参数已经绑定到调用处的实参
+func MatchHelperCall(ctx *analyzer.Context, node ast.Node, contextTypeName string, callback func(ctx *analyzer.Context, def *analyzer.FuncDefinition)) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	passContext := lo.ContainsBy(call.Args, func(arg ast.Expr) bool {
+		t := ctx.Package().TypesInfo.TypeOf(arg)
+		return t != nil && t.String() == contextTypeName
+	})
+	if !passContext {
+		return
+	}
+	def := ctx.GetFuncDefinition(call)
+	if def == nil {
+		return
+	}
+	if helperCtx := ctx.EnterFunc(def, call); helperCtx != nil {
+		callback(helperCtx, def)
+	}
+}
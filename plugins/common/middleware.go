@@ -0,0 +1,69 @@
+package common
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+
+	analyzer "github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/spec"
+	"github.com/samber/lo"
+)
+
+// ResolveMiddlewares 解析中间件参数对应的函数定义. e.g.
+//
+//	g.Use(Logger, AuthRequired())
+//	auth := AuthRequired(); g.Use(auth)
+//
+// 无法找到定义的中间件 (e.g. 第三方包中的中间件) 会被忽略
+func ResolveMiddlewares(ctx *analyzer.Context, args []ast.Expr) (res []*analyzer.FuncDefinition) {
+	for _, arg := range args {
+		arg = ctx.ResolveExpr(arg)
+		if ident, ok := arg.(*ast.Ident); ok {
+			if v, ok := ctx.Package().TypesInfo.ObjectOf(ident).(*types.Var); ok {
+				if value := ctx.LookupVarValue(v); value != nil {
+					arg = value
+				}
+			}
+		}
+		if def := ctx.GetFuncDefinition(arg); def != nil {
+			res = append(res, def)
+		}
+	}
+	return
+}
+
+// ParseMiddlewares 使用 parse 依次解析中间件, 并将得到的参数/响应以及中间件注释中的 @security 合并到 api 中.
+// 路由自身的解析结果优先
+func ParseMiddlewares(ctx *analyzer.Context, api *analyzer.API, middlewares []*analyzer.FuncDefinition, parse func(ctx *analyzer.Context, api *analyzer.API, def *analyzer.FuncDefinition)) {
+	for _, def := range middlewares {
+		mw := analyzer.NewAPI(api.Method, api.FullPath)
+		mw.Spec.OperationID = api.Spec.OperationID
+		parse(ctx.NewEnv().WithPackage(def.Pkg()).WithFile(def.File()), mw, def)
+		mw.Spec.Security = ctx.WithPackage(def.Pkg()).ParseComment(def.Decl.Doc).Security()
+		mergeSpec(api.Spec, mw.Spec)
+	}
+}
+
+func mergeSpec(dst, src *analyzer.APISpec) {
+	for _, param := range src.Parameters {
+		dst.AddParameter(param)
+	}
+	for code, res := range src.Responses {
+		if _, ok := dst.Responses[code]; !ok {
+			dst.Responses[code] = res
+		}
+	}
+	if src.Security == nil {
+		return
+	}
+	if dst.Security == nil {
+		dst.Security = spec.NewSecurityRequirements()
+	}
+	for _, requirement := range *src.Security {
+		exists := lo.ContainsBy(*dst.Security, func(item spec.SecurityRequirement) bool { return reflect.DeepEqual(item, requirement) })
+		if !exists {
+			dst.Security.With(requirement)
+		}
+	}
+}
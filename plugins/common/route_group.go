@@ -0,0 +1,111 @@
+package common
+
+import (
+	"go/ast"
+	"go/types"
+
+	analyzer "github.com/gotomicro/eapi"
+)
+
+// PropagateRouteGroups 用于跨函数/跨包传递路由分组. 被调用函数的参数或者方法接收者的字段是已知的路由分组时,
+// 在绑定路由分组之后解析被调用函数的函数体. e.g.
+//
+//	user.RegisterRoutes(api.Group("/users"))
+//	h := &Handler{router: api}
+//	h.RegisterRoutes()
+//
+// 参数使用参数名作为 Environment 的 key, 结构体字段使用字段对应的 types.Object 作为 key.
+// routeGroupOf 用于获取表达式对应的路由分组
+func PropagateRouteGroups(ctx *analyzer.Context, call *ast.CallExpr, routeGroupOf func(expr ast.Expr) *analyzer.RouteGroup) {
+	def := ctx.GetFuncDefinition(call)
+	if def == nil {
+		return
+	}
+	bindings := make(map[interface{}]*analyzer.RouteGroup)
+
+	for i, param := range funcParams(def.Decl.Type) {
+		if i >= len(call.Args) {
+			break
+		}
+		if param == nil || param.Name == "_" {
+			continue
+		}
+		if rg := routeGroupOf(call.Args[i]); rg != nil {
+			bindings[param.Name] = rg
+		}
+	}
+
+	if sel, ok := call.Fun.(*ast.SelectorExpr); ok && def.Decl.Recv != nil {
+		// 路由分组自身的方法 (e.g. g.Use(...)) 不需要传递
+		if routeGroupOf(sel.X) != nil {
+			return
+		}
+		for _, field := range structFields(ctx.Package().TypesInfo.TypeOf(sel.X)) {
+			if rg, ok := ctx.Env.Lookup(field).(*analyzer.RouteGroup); ok {
+				bindings[field] = rg
+			}
+		}
+	}
+
+	if len(bindings) == 0 {
+		return
+	}
+	ctx.AnalyzeFunc(def, func(env *analyzer.Environment) {
+		for k, rg := range bindings {
+			env.Define(k, rg)
+		}
+	})
+}
+
+// BindRouteGroupFields 记录结构体字面量中字段对应的路由分组. e.g. &Handler{router: api}
+func BindRouteGroupFields(ctx *analyzer.Context, lit *ast.CompositeLit, routeGroupOf func(expr ast.Expr) *analyzer.RouteGroup) {
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		field, ok := ctx.Package().TypesInfo.ObjectOf(key).(*types.Var)
+		if !ok || !field.IsField() {
+			continue
+		}
+		if rg := routeGroupOf(kv.Value); rg != nil {
+			ctx.Env.Define(field, rg)
+		}
+	}
+}
+
+// funcParams 返回按声明位置排列的函数参数, 未命名的参数为 nil. 可变参数对应的是切片, 不会是路由分组, 所以不包含在内
+func funcParams(fnType *ast.FuncType) (params []*ast.Ident) {
+	for _, field := range fnType.Params.List {
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			break
+		}
+		if len(field.Names) == 0 {
+			params = append(params, nil)
+			continue
+		}
+		params = append(params, field.Names...)
+	}
+	return
+}
+
+func structFields(t types.Type) (fields []*types.Var) {
+	if t == nil {
+		return
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		fields = append(fields, st.Field(i))
+	}
+	return
+}
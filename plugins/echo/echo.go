@@ -24,6 +24,9 @@ const (
 	echoInstanceTypeName = "*github.com/labstack/echo/v4.Echo"
 	echoGroupTypeName    = "*github.com/labstack/echo/v4.Group"
 	echoGroupMethodName  = "Group"
+	routerAddMethodName  = "Add"
+	routerAnyMethodName  = "Any"
+	routerUseMethodName  = "Use"
 )
 
 type Plugin struct {
@@ -49,6 +52,11 @@ func (p *Plugin) Analyze(ctx *eapi.Context, node ast.Node) {
 
 	case *ast.CallExpr:
 		p.callExpr(ctx, node)
+		p.useExpr(ctx, node)
+		common.PropagateRouteGroups(ctx, node, func(expr ast.Expr) *eapi.RouteGroup { return p.routeGroupOf(ctx, expr) })
+
+	case *ast.CompositeLit:
+		common.BindRouteGroupFields(ctx, node, func(expr ast.Expr) *eapi.RouteGroup { return p.routeGroupOf(ctx, expr) })
 	}
 }
 
@@ -56,64 +64,116 @@ func (p *Plugin) assignStmt(ctx *eapi.Context, assign *ast.AssignStmt) {
 	if len(assign.Rhs) != 1 || len(assign.Lhs) != 1 {
 		return
 	}
-
-	callRule := eapi.NewCallRule().
-		WithRule(echoInstanceTypeName, echoGroupMethodName).
-		WithRule(echoGroupTypeName, echoGroupMethodName)
-	for _, router := range p.config.RouterNames {
-		callRule = callRule.WithRule(router, echoGroupMethodName)
+	rg := p.routeGroupOf(ctx, assign.Rhs[0])
+	if rg == nil {
+		return
 	}
 
-	rh := assign.Rhs[0]
-	ctx.MatchCall(
-		rh,
-		callRule,
-		func(callExpr *ast.CallExpr, typeName, fnName string) {
-			if len(callExpr.Args) <= 0 {
-				return
+	switch lh := assign.Lhs[0].(type) {
+	case *ast.Ident:
+		switch assign.Tok {
+		case token.ASSIGN:
+			env := ctx.Env.Resolve(lh.Name)
+			if env == nil {
+				ctx.Env.Define(lh.Name, rg)
+			} else {
+				env.Assign(lh.Name, rg)
 			}
-			arg0, ok := callExpr.Args[0].(*ast.BasicLit)
-			if !ok {
+
+		case token.DEFINE:
+			ctx.Env.Define(lh.Name, rg)
+		}
+	case *ast.SelectorExpr: // h.group = g
+		obj := ctx.Package().TypesInfo.ObjectOf(lh.Sel)
+		if obj == nil {
+			return
+		}
+		env := ctx.Env.Resolve(obj)
+		if env == nil {
+			ctx.Env.Define(obj, rg)
+		} else {
+			env.Assign(obj, rg)
+		}
+	}
+}
+
+// routeGroupOf 获取表达式对应的路由分组. e.g. g / h.group / e.Group("/api"). 未知的路由分组返回 nil
+func (p *Plugin) routeGroupOf(ctx *eapi.Context, expr ast.Expr) *eapi.RouteGroup {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return p.routeGroupOf(ctx, expr.X)
+	case *ast.Ident:
+		rg, _ := ctx.Env.Lookup(expr.Name).(*eapi.RouteGroup)
+		return rg
+	case *ast.SelectorExpr:
+		obj := ctx.Package().TypesInfo.ObjectOf(expr.Sel)
+		if obj == nil {
+			return nil
+		}
+		rg, _ := ctx.Env.Lookup(obj).(*eapi.RouteGroup)
+		return rg
+	case *ast.CallExpr:
+		callRule := eapi.NewCallRule().
+			WithRule(echoInstanceTypeName, echoGroupMethodName).
+			WithRule(echoGroupTypeName, echoGroupMethodName)
+		for _, router := range p.config.RouterNames {
+			callRule = callRule.WithRule(router, echoGroupMethodName)
+		}
+
+		var res *eapi.RouteGroup
+		ctx.MatchCall(expr, callRule, func(call *ast.CallExpr, typeName, fnName string) {
+			if len(call.Args) <= 0 {
 				return
 			}
-			selExpr := callExpr.Fun.(*ast.SelectorExpr)
-			xIdent, ok := selExpr.X.(*ast.Ident)
+			groupPath, ok := ctx.EvalString(call.Args[0])
 			if !ok {
+				fmt.Fprintf(os.Stderr, "unable to resolve route group path at %s\n", ctx.LineColumn(call.Args[0].Pos()))
 				return
 			}
-			var prefix = ""
-			v := ctx.Env.Lookup(xIdent.Name)
-			if rg, ok := v.(*eapi.RouteGroup); ok {
-				prefix = rg.Prefix
+			var prefix string
+			parent := p.routeGroupOf(ctx, call.Fun.(*ast.SelectorExpr).X)
+			if parent != nil {
+				prefix = parent.Prefix
 			}
-			rg := &eapi.RouteGroup{Prefix: path.Join(prefix, p.normalizePath(strings.Trim(arg0.Value, "\"")))}
-			lh := assign.Lhs[0]
-			lhIdent, ok := lh.(*ast.Ident)
+			// e.Group(prefix, middlewares...)
+			res = eapi.NewRouteGroup(parent, path.Join(prefix, p.normalizePath(groupPath)))
+			res.Use(common.ResolveMiddlewares(ctx, call.Args[1:])...)
+		})
+		return res
+	}
+	return nil
+}
+
+// useExpr 记录通过 e.Use(middlewares...) 添加的中间件
+func (p *Plugin) useExpr(ctx *eapi.Context, callExpr *ast.CallExpr) {
+	callRule := eapi.NewCallRule().WithRule(echoInstanceTypeName, routerUseMethodName).
+		WithRule(echoGroupTypeName, routerUseMethodName)
+	for _, router := range p.config.RouterNames {
+		callRule = callRule.WithRule(router, routerUseMethodName)
+	}
+
+	ctx.MatchCall(callExpr, callRule, func(call *ast.CallExpr, typeName, fnName string) {
+		x := call.Fun.(*ast.SelectorExpr).X
+		rg := p.routeGroupOf(ctx, x)
+		if rg == nil {
+			// 根路由. e.g. e := echo.New(); e.Use(...)
+			ident, ok := x.(*ast.Ident)
 			if !ok {
 				return
 			}
-			switch assign.Tok {
-			case token.ASSIGN:
-				env := ctx.Env.Resolve(lhIdent.Name)
-				if env == nil {
-					ctx.Env.Define(lhIdent.Name, rg)
-				} else {
-					env.Assign(lhIdent.Name, rg)
-				}
-
-			case token.DEFINE:
-				ctx.Env.Define(lhIdent.Name, rg)
-			}
-		},
-	)
-
+			rg = eapi.NewRouteGroup(nil, "")
+			ctx.Env.Define(ident.Name, rg)
+		}
+		rg.Use(common.ResolveMiddlewares(ctx, call.Args)...)
+	})
 }
 
 func (p *Plugin) callExpr(ctx *eapi.Context, callExpr *ast.CallExpr) {
-	callRule := eapi.NewCallRule().WithRule(echoInstanceTypeName, routeMethods...).
-		WithRule(echoGroupTypeName, routeMethods...)
+	fnNames := append([]string{routerAddMethodName, routerAnyMethodName}, routeMethods...)
+	callRule := eapi.NewCallRule().WithRule(echoInstanceTypeName, fnNames...).
+		WithRule(echoGroupTypeName, fnNames...)
 	for _, router := range p.config.RouterNames {
-		callRule = callRule.WithRule(router, routeMethods...)
+		callRule = callRule.WithRule(router, fnNames...)
 	}
 
 	ctx.MatchCall(
@@ -124,38 +184,64 @@ func (p *Plugin) callExpr(ctx *eapi.Context, callExpr *ast.CallExpr) {
 			if comment.Ignore() {
 				return
 			}
-			api := p.parseAPI(ctx, callExpr, comment)
-			if api == nil {
-				return
+
+			var methods []string
+			args := callExpr.Args
+			switch fnName {
+			case routerAddMethodName: // e.Add(method, path, handler, middleware...)
+				if len(args) < 3 {
+					return
+				}
+				method, ok := ctx.EvalString(args[0])
+				if !ok {
+					fmt.Fprintf(os.Stderr, "unable to resolve route method at %s\n", ctx.LineColumn(args[0].Pos()))
+					return
+				}
+				methods, args = []string{strings.ToUpper(method)}, args[1:]
+			case routerAnyMethodName: // e.Any(path, handler, middleware...)
+				methods = routeMethods
+			default:
+				methods = []string{fnName}
+			}
+
+			for _, method := range methods {
+				api := p.parseAPI(ctx, callExpr, method, args, comment)
+				if api == nil {
+					continue
+				}
+				if fnName == routerAnyMethodName {
+					api.Spec.OperationID += "_" + strings.ToLower(method)
+				}
+				ctx.AddAPI(api)
 			}
-			ctx.AddAPI(api)
 		},
 	)
 }
 
-func (p *Plugin) parseAPI(ctx *eapi.Context, callExpr *ast.CallExpr, comment *eapi.Comment) (api *eapi.API) {
-	if len(callExpr.Args) < 2 {
+// parseAPI 解析路由声明. args 为 path 及之后的参数: (path, handler, middleware...)
+func (p *Plugin) parseAPI(ctx *eapi.Context, callExpr *ast.CallExpr, method string, args []ast.Expr, comment *eapi.Comment) (api *eapi.API) {
+	if len(args) < 2 {
 		return
 	}
-	arg0, ok := callExpr.Args[0].(*ast.BasicLit)
+	routePath, ok := ctx.EvalString(args[0])
 	if !ok {
+		fmt.Fprintf(os.Stderr, "unable to resolve route path at %s\n", ctx.LineColumn(args[0].Pos()))
 		return
 	}
 
-	selExpr := callExpr.Fun.(*ast.SelectorExpr)
 	var prefix string
-	if xIdent, ok := selExpr.X.(*ast.Ident); ok {
-		v := ctx.Env.Lookup(xIdent.Name)
-		if rg, ok := v.(*eapi.RouteGroup); ok {
-			prefix = rg.Prefix
-		}
+	var middlewares []*eapi.FuncDefinition
+	if rg := p.routeGroupOf(ctx, callExpr.Fun.(*ast.SelectorExpr).X); rg != nil {
+		prefix, middlewares = rg.Prefix, rg.Middlewares
 	}
+	// e.GET(path, handler, middlewares...)
+	middlewares = append(append([]*eapi.FuncDefinition{}, middlewares...), common.ResolveMiddlewares(ctx, args[2:])...)
 
-	handlerFn := p.getHandlerFn(ctx, callExpr)
-	if handlerFn == nil {
+	handler := common.ResolveHandler(ctx, ctx.ResolveExpr(args[1]), p.isHandler)
+	if handler == nil {
 		return
 	}
-	typeName, methodName := utils.GetFuncInfo(handlerFn)
+	typeName, methodName := utils.GetFuncInfo(handler.Fn)
 	handlerDef := ctx.GetDefinition(typeName, methodName)
 	if handlerDef == nil {
 		fmt.Fprintf(os.Stderr, "handler function %s.%s not found\n", typeName, methodName)
@@ -166,8 +252,7 @@ func (p *Plugin) parseAPI(ctx *eapi.Context, callExpr *ast.CallExpr, comment *ea
 		return
 	}
 
-	fullPath := path.Join(prefix, p.normalizePath(strings.Trim(arg0.Value, "\"")))
-	method := selExpr.Sel.Name
+	fullPath := path.Join(prefix, p.normalizePath(routePath))
 	api = eapi.NewAPI(method, fullPath)
 	api.Spec.LoadFromComment(ctx, comment)
 	api.Spec.LoadFromFuncDecl(ctx, handlerFnDef.Decl)
@@ -178,25 +263,29 @@ func (p *Plugin) parseAPI(ctx *eapi.Context, callExpr *ast.CallExpr, comment *ea
 		}
 		api.Spec.OperationID = id
 	}
-	newHandlerAnalyzer(
+	defer common.ParseMiddlewares(ctx, api, middlewares, p.parseMiddleware)
+	parser := newHandlerAnalyzer(
 		ctx.NewEnv().WithPackage(handlerFnDef.Pkg()).WithFile(handlerFnDef.File()),
 		api,
 		handlerFnDef.Decl,
-	).WithConfig(&p.config).Parse()
+	).WithConfig(&p.config)
+	if handler.IsAdapter() {
+		handler.ParseAdapter(ctx, api, parser.paramNameParser)
+		return
+	}
+	parser.Parse()
 
 	return
 }
 
-func (p *Plugin) getHandlerFn(ctx *eapi.Context, callExpr *ast.CallExpr) (handlerFn *types.Func) {
-	handlerArg := callExpr.Args[len(callExpr.Args)-1]
-	if call, ok := handlerArg.(*ast.CallExpr); ok {
-		nestedCall := utils.UnwrapCall(call)
-		if len(nestedCall.Args) <= 0 {
-			return
-		}
-		handlerArg = nestedCall.Args[0]
-	}
-	return ctx.GetFuncFromAstNode(handlerArg)
+// parseMiddleware 和 handler 函数一样解析中间件函数
+func (p *Plugin) parseMiddleware(ctx *eapi.Context, api *eapi.API, def *eapi.FuncDefinition) {
+	newHandlerAnalyzer(ctx, api, def.Decl).WithConfig(&p.config).Parse()
+}
+
+// isHandler 判断是否是 echo.HandlerFunc
+func (p *Plugin) isHandler(sig *types.Signature) bool {
+	return sig.Params().Len() == 1 && sig.Results().Len() == 1 && sig.Params().At(0).Type().String() == echoContextIdentName
 }
 
 var (
@@ -13,10 +13,13 @@ import (
 	"github.com/robertkrimen/otto"
 )
 
-const echoContextIdentName = "github.com/labstack/echo/v4.Context"
+const (
+	echoContextIdentName      = "github.com/labstack/echo/v4.Context"
+	echoDefaultBinderTypeName = "*github.com/labstack/echo/v4.DefaultBinder"
+)
 
 var (
-	interestedEchoContextMethods = []string{"Bind", "JSON", "QueryParam", "Param", "FormValue", "XML", "XMLPretty", "Redirect", "FormFile"}
+	interestedEchoContextMethods = []string{"Bind", "JSON", "QueryParam", "Param", "FormValue", "XML", "XMLPretty", "Redirect", "FormFile", "Cookie", "SetCookie", "JSONPretty", "NoContent", "String", "HTML", "HTMLBlob", "Blob", "Stream", "File", "Attachment", "Inline"}
 )
 
 type handlerAnalyzer struct {
@@ -26,6 +29,9 @@ type handlerAnalyzer struct {
 	decl *ast.FuncDecl
 
 	c *common.Config
+
+	// 通过 c.SetCookie 设置的 cookie
+	setCookies []string
 }
 
 func newHandlerAnalyzer(ctx *analyzer.Context, api *analyzer.API, decl *ast.FuncDecl) *handlerAnalyzer {
@@ -38,6 +44,11 @@ func (p *handlerAnalyzer) WithConfig(c *common.Config) *handlerAnalyzer {
 }
 
 func (p *handlerAnalyzer) Parse() {
+	p.parseBody()
+	common.AddSetCookieHeader(p.spec, p.setCookies)
+}
+
+func (p *handlerAnalyzer) parseBody() {
 	ast.Inspect(p.decl, func(node ast.Node) bool {
 		customRuleAnalyzer := common.NewCustomRuleAnalyzer(
 			p.ctx,
@@ -60,7 +71,7 @@ func (p *handlerAnalyzer) Parse() {
 				switch fnName {
 				case "Bind":
 					p.parseBinding(call)
-				case "JSON":
+				case "JSON", "JSONPretty":
 					p.parseResBody(call, "application/json")
 				case "XML", "XMLPretty":
 					p.parseResBody(call, "application/xml")
@@ -74,14 +85,49 @@ func (p *handlerAnalyzer) Parse() {
 					p.parseFormData(call, "file")
 				case "Redirect":
 					p.parseRedirectRes(call)
-					// TODO: supporting more methods (FileForm(), HTML(), Data(), etc...)
+				case "Cookie": // cookie parameter
+					p.parsePrimitiveParam(call, "cookie")
+				case "SetCookie":
+					p.parseSetCookie(call)
+				case "NoContent":
+					p.parseStatusRes(call)
+				case "String": // c.String(code, s)
+					p.parseRawRes(call, call.Args[0], "text/plain", spec.NewStringSchema())
+				case "HTML", "HTMLBlob": // c.HTML(code, html)
+					p.parseRawRes(call, call.Args[0], "text/html", spec.NewStringSchema())
+				case "Blob", "Stream": // c.Blob(code, contentType, b)
+					p.parseBlobRes(call)
+				case "File", "Attachment", "Inline":
+					p.parseRawRes(call, nil, "application/octet-stream", spec.NewStringSchema().WithFormat("binary"))
 				}
 			},
 		)
+		p.ctx.MatchCall(node,
+			analyzer.NewCallRule().WithRule(echoDefaultBinderTypeName, "BindHeaders"),
+			func(call *ast.CallExpr, typeName, fnName string) {
+				p.parseBindHeaders(call)
+			},
+		)
+		common.MatchRequestHeaderGet(p.ctx, node, func(call *ast.CallExpr) { // c.Request().Header.Get("X-Foo")
+			p.parsePrimitiveParam(call, "header")
+		})
+		common.MatchRequestCookie(p.ctx, node, func(call *ast.CallExpr) { // c.Request().Cookie("session")
+			p.parsePrimitiveParam(call, "cookie")
+		})
+		common.MatchHelperCall(p.ctx, node, echoContextIdentName, func(ctx *analyzer.Context, def *analyzer.FuncDefinition) { // resp.OK(c, data)
+			p.parseHelper(ctx, def)
+		})
 		return true
 	})
 }
 
+// parseHelper 解析 handler 调用的 helper 函数中的参数和响应
+func (p *handlerAnalyzer) parseHelper(ctx *analyzer.Context, def *analyzer.FuncDefinition) {
+	helper := newHandlerAnalyzer(ctx, p.api, def.Decl).WithConfig(p.c)
+	helper.parseBody()
+	p.setCookies = append(p.setCookies, helper.setCookies...)
+}
+
 func (p *handlerAnalyzer) paramNameParser(field string, tags map[string]string) (name string, in string) {
 	name, ok := tags["query"]
 	if ok {
@@ -120,6 +166,17 @@ func (p *handlerAnalyzer) parseBinding(call *ast.CallExpr) {
 	}
 }
 
+// parseBindHeaders 解析 (&echo.DefaultBinder{}).BindHeaders(c, &req)
+func (p *handlerAnalyzer) parseBindHeaders(call *ast.CallExpr) {
+	if len(call.Args) != 2 {
+		return
+	}
+	params := analyzer.NewParamParser(p.ctx, common.HeaderNameParser).Parse(call.Args[1])
+	for _, param := range params {
+		p.spec.AddParameter(param)
+	}
+}
+
 func (p *handlerAnalyzer) parseResBody(call *ast.CallExpr, contentType string) {
 	if len(call.Args) < 2 {
 		return
@@ -141,6 +198,16 @@ func (p *handlerAnalyzer) parseResBody(call *ast.CallExpr, contentType string) {
 	p.spec.AddResponse(statusCode, res)
 }
 
+// parseSetCookie 解析 c.SetCookie(&http.Cookie{Name: "session"})
+func (p *handlerAnalyzer) parseSetCookie(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	if name, ok := common.CookieName(p.ctx, call.Args[0]); ok {
+		p.setCookies = append(p.setCookies, name)
+	}
+}
+
 func (p *handlerAnalyzer) parseRedirectRes(call *ast.CallExpr) {
 	if len(call.Args) == 0 {
 		return
@@ -159,8 +226,53 @@ func (p *handlerAnalyzer) parseRedirectRes(call *ast.CallExpr) {
 	p.spec.AddResponse(statusCode, res)
 }
 
+// parseStatusRes 解析没有响应 body 的调用. e.g. c.NoContent(http.StatusNoContent)
+func (p *handlerAnalyzer) parseStatusRes(call *ast.CallExpr) {
+	if len(call.Args) == 0 {
+		return
+	}
+	statusCode := p.ctx.ParseStatusCode(call.Args[0])
+	if p.spec.Responses.Get(statusCode) != nil {
+		return
+	}
+
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	p.spec.AddResponse(statusCode, res)
+}
+
+// parseRawRes 解析指定 content-type 的响应. status 为空时状态码为 200. e.g. c.String() / c.HTML() / c.File()
+func (p *handlerAnalyzer) parseRawRes(call *ast.CallExpr, status ast.Expr, contentType string, schema *spec.Schema) {
+	statusCode := http.StatusOK
+	if status != nil {
+		statusCode = p.ctx.ParseStatusCode(status)
+	}
+
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	res.Content = spec.NewContentWithSchema(schema, []string{contentType})
+	p.spec.AddResponse(statusCode, res)
+}
+
+// parseBlobRes 解析 c.Blob(code, contentType, b) / c.Stream(code, contentType, r). 无法确定 contentType 时使用 application/octet-stream
+func (p *handlerAnalyzer) parseBlobRes(call *ast.CallExpr) {
+	if len(call.Args) != 3 {
+		return
+	}
+	contentType, ok := p.ctx.EvalString(call.Args[1])
+	if !ok {
+		contentType = "application/octet-stream"
+	}
+	p.parseRawRes(call, call.Args[0], contentType, spec.NewStringSchema().WithFormat("binary"))
+}
+
 func (p *handlerAnalyzer) parsePrimitiveParam(call *ast.CallExpr, in string) {
 	param := p.primitiveParam(call, in)
+	if param == nil {
+		return
+	}
 	p.spec.AddParameter(param)
 }
 
@@ -235,10 +347,19 @@ func (p *handlerAnalyzer) primitiveParam(call *ast.CallExpr, in string) *spec.Pa
 		res = spec.NewPathParameter(name).WithSchema(paramSchema)
 	case "query":
 		res = spec.NewQueryParameter(name).WithSchema(paramSchema)
+	case "header":
+		res = spec.NewHeaderParameter(name).WithSchema(paramSchema)
+	case "cookie":
+		res = spec.NewCookieParameter(name).WithSchema(paramSchema)
+	default:
+		return nil
 	}
 
 	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
 	res.Description = comment.Text()
+	if in == "header" || in == "cookie" {
+		res.Required = comment.Required()
+	}
 
 	return res
 }
@@ -0,0 +1,366 @@
+package ego
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/tag"
+	"github.com/gotomicro/eapi/utils"
+	"github.com/samber/lo"
+)
+
+const ginContextTypeName = "*github.com/gin-gonic/gin.Context"
+
+var (
+	// gin.Context 中输出 JSON 响应的方法, 参数均为 (code int, obj any)
+	ginJSONMethods = []string{"JSON", "IndentedJSON", "SecureJSON", "PureJSON", "AsciiJSON", "AbortWithStatusJSON"}
+	// gin.Context 中绑定请求参数的方法及其对应的 content-type
+	ginBindMethods = map[string]string{
+		"Bind":           "",
+		"ShouldBind":     "",
+		"BindJSON":       eapi.MimeTypeJson,
+		"ShouldBindJSON": eapi.MimeTypeJson,
+		"BindXML":        eapi.MimeApplicationXml,
+		"ShouldBindXML":  eapi.MimeApplicationXml,
+	}
+)
+
+// parseContextWrapper 为 handler 中调用的 context wrapper 方法推导自定义规则 (等价于在 properties 中配置 request/response 规则). e.g.
+//
+//	type Context struct {
+//		*gin.Context
+//	}
+//
+//	func (c *Context) JSONOK(data interface{}) {
+//		c.JSON(http.StatusOK, Res{Code: 0, Msg: "ok", Data: data})
+//	}
+//
+// 推导出的规则由 gin 插件在解析 handler 时使用, 所以总是返回 false
+func (p *Plugin) parseContextWrapper(ctx *eapi.Context, api *eapi.API, handlerArg ast.Expr, def *eapi.FuncDefinition) bool {
+	defCtx := ctx.NewEnv().WithPackage(def.Pkg()).WithFile(def.File())
+	ast.Inspect(def.Decl, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		fn := defCtx.GetFuncFromAstNode(sel)
+		if fn != nil {
+			p.inferWrapperRule(defCtx, fn)
+		}
+		return true
+	})
+	return false
+}
+
+func (p *Plugin) inferWrapperRule(ctx *eapi.Context, fn *types.Func) {
+	sig := fn.Type().(*types.Signature)
+	if sig.Recv() == nil || !isContextWrapper(sig.Recv().Type()) {
+		return
+	}
+	typeName, methodName := utils.GetFuncInfo(fn)
+	key := typeName + "." + methodName
+	if _, ok := p.wrapperMethods[key]; ok {
+		return
+	}
+	p.wrapperMethods[key] = struct{}{}
+
+	def, ok := ctx.GetDefinition(typeName, methodName).(*eapi.FuncDefinition)
+	if !ok || def.Decl.Body == nil {
+		return
+	}
+	a := newWrapperAnalyzer(ctx.NewEnv().WithPackage(def.Pkg()).WithFile(def.File()), def.Decl)
+
+	// properties 中配置的规则优先
+	config := p.Config()
+	configured := lo.ContainsBy(config.Response, func(rule common.ResponseRule) bool {
+		return rule.Type == typeName && rule.Method == methodName
+	})
+	if res := a.responseReturn(); res != nil && !configured {
+		config.Response = append(config.Response, common.ResponseRule{Type: typeName, Method: methodName, Return: res})
+	}
+	configured = lo.ContainsBy(config.Request, func(rule common.RequestRule) bool {
+		return rule.Type == typeName && rule.Method == methodName
+	})
+	if req := a.requestReturn(); req != nil && !configured {
+		config.Request = append(config.Request, common.RequestRule{Type: typeName, Method: methodName, Return: req})
+	}
+}
+
+// isContextWrapper 判断类型是否内嵌了 *gin.Context
+func isContextWrapper(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if field.Embedded() && field.Type().String() == ginContextTypeName {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapperAnalyzer 解析 context wrapper 方法的方法体
+type wrapperAnalyzer struct {
+	ctx  *eapi.Context
+	decl *ast.FuncDecl
+
+	// 方法参数, 下标与调用时的 args 对应
+	params   []types.Object
+	variadic bool
+}
+
+func newWrapperAnalyzer(ctx *eapi.Context, decl *ast.FuncDecl) *wrapperAnalyzer {
+	a := &wrapperAnalyzer{ctx: ctx, decl: decl}
+	for _, field := range decl.Type.Params.List {
+		_, a.variadic = field.Type.(*ast.Ellipsis)
+		for _, name := range field.Names {
+			a.params = append(a.params, ctx.Package().TypesInfo.Defs[name])
+		}
+	}
+	return a
+}
+
+// responseReturn 根据方法中的 c.JSON(code, obj) 推导响应
+func (a *wrapperAnalyzer) responseReturn() (res *common.ResponseReturn) {
+	a.findCall(eapi.NewCallRule().WithRule(ginContextTypeName, ginJSONMethods...), func(call *ast.CallExpr, fnName string) {
+		if len(call.Args) != 2 {
+			return
+		}
+		data := a.dataSchemaOf(call.Args[1])
+		if data == nil {
+			return
+		}
+		status := a.argExpr(call.Args[0])
+		if status == "" {
+			status = strconv.Itoa(a.ctx.ParseStatusCode(call.Args[0]))
+		}
+		res = &common.ResponseReturn{Status: status, Data: data, ContentType: eapi.MimeTypeJson}
+	})
+	return
+}
+
+// requestReturn 根据方法中的 c.ShouldBind(obj) 等调用推导请求参数
+func (a *wrapperAnalyzer) requestReturn() (req *common.RequestReturn) {
+	a.findCall(eapi.NewCallRule().WithRule(ginContextTypeName, lo.Keys(ginBindMethods)...), func(call *ast.CallExpr, fnName string) {
+		if len(call.Args) != 1 {
+			return
+		}
+		arg := a.argExpr(call.Args[0])
+		if arg == "" {
+			return
+		}
+		req = &common.RequestReturn{Data: &common.DataSchema{Type: common.DataType(arg)}, ContentType: ginBindMethods[fnName]}
+	})
+	return
+}
+
+// findCall 查找方法体中第一个符合规则的调用
+func (a *wrapperAnalyzer) findCall(rule *eapi.CallRule, callback func(call *ast.CallExpr, fnName string)) {
+	var found bool
+	ast.Inspect(a.decl.Body, func(node ast.Node) bool {
+		if found {
+			return false
+		}
+		a.ctx.MatchCall(node, rule, func(call *ast.CallExpr, typeName, fnName string) {
+			found = true
+			callback(call, fnName)
+		})
+		return true
+	})
+}
+
+// argExpr 如果表达式来自方法参数, 返回其在调用处对应的 args 表达式. e.g. data => "args[0]", data[0] => "args[0]" (data 为可变参数)
+func (a *wrapperAnalyzer) argExpr(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return a.argExpr(expr.X)
+	case *ast.Ident:
+		index := a.paramIndex(expr)
+		if index < 0 || (a.variadic && index == len(a.params)-1) {
+			return ""
+		}
+		return "args[" + strconv.Itoa(index) + "]"
+	case *ast.IndexExpr:
+		ident, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+		index := a.paramIndex(ident)
+		if index < 0 || !a.variadic || index != len(a.params)-1 {
+			return ""
+		}
+		tv, ok := a.ctx.Package().TypesInfo.Types[expr.Index]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+			return ""
+		}
+		offset, _ := constant.Int64Val(tv.Value)
+		return "args[" + strconv.Itoa(index+int(offset)) + "]"
+	}
+	return ""
+}
+
+func (a *wrapperAnalyzer) paramIndex(ident *ast.Ident) int {
+	obj := a.ctx.Package().TypesInfo.ObjectOf(ident)
+	if obj == nil {
+		return -1
+	}
+	return lo.IndexOf(a.params, obj)
+}
+
+func (a *wrapperAnalyzer) dataSchemaOf(expr ast.Expr) *common.DataSchema {
+	if arg := a.argExpr(expr); arg != "" {
+		return &common.DataSchema{Type: common.DataType(arg)}
+	}
+
+	var values map[string]ast.Expr
+	switch e := expr.(type) {
+	case *ast.UnaryExpr, *ast.CompositeLit:
+		values = a.compositeValues(e)
+	case *ast.Ident: // 局部变量. e.g. res := new(Res); res.Data = data
+		obj := a.ctx.Package().TypesInfo.ObjectOf(e)
+		if obj == nil {
+			return nil
+		}
+		values = a.fieldValuesOf(obj)
+	}
+
+	t := a.ctx.Package().TypesInfo.TypeOf(expr)
+	if t == nil {
+		return nil
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return typeDataSchema(t)
+	}
+	return a.structDataSchema(st, values)
+}
+
+func (a *wrapperAnalyzer) structDataSchema(st *types.Struct, values map[string]ast.Expr) *common.DataSchema {
+	res := &common.DataSchema{Type: common.DataTypeObject, Properties: make(common.DataProperties)}
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		name := field.Name()
+		var optional bool
+		if value, ok := tag.Parse(st.Tag(i))["json"]; ok {
+			jsonName, options, _ := strings.Cut(value, ",")
+			if jsonName == "-" {
+				continue
+			}
+			if jsonName != "" {
+				name = jsonName
+			}
+			optional = strings.Contains(options, "omitempty")
+		}
+
+		var schema *common.DataSchema
+		if value, ok := values[field.Name()]; ok {
+			if arg := a.argExpr(value); arg != "" {
+				schema = &common.DataSchema{Type: common.DataType(arg)}
+			}
+		}
+		if schema == nil {
+			schema = typeDataSchema(field.Type())
+		}
+		schema.Optional = optional
+		res.Properties[name] = schema
+	}
+	return res
+}
+
+// compositeValues 获取结构体字面量中各个字段的值. e.g. &Res{Code: 0, Data: data}
+func (a *wrapperAnalyzer) compositeValues(expr ast.Expr) map[string]ast.Expr {
+	values := make(map[string]ast.Expr)
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return values
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); ok {
+			values[key.Name] = kv.Value
+		}
+	}
+	return values
+}
+
+// fieldValuesOf 获取局部变量各个字段被赋予的值. 同一个字段被多次赋值时, 优先使用来自方法参数的值
+func (a *wrapperAnalyzer) fieldValuesOf(obj types.Object) map[string]ast.Expr {
+	values := make(map[string]ast.Expr)
+	set := func(name string, value ast.Expr) {
+		if old, ok := values[name]; ok && a.argExpr(old) != "" {
+			return
+		}
+		values[name] = value
+	}
+	ast.Inspect(a.decl.Body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return true
+		}
+		for i, lh := range assign.Lhs {
+			switch lh := lh.(type) {
+			case *ast.Ident: // res := &Res{...}
+				if a.ctx.Package().TypesInfo.ObjectOf(lh) != obj {
+					continue
+				}
+				for name, value := range a.compositeValues(assign.Rhs[i]) {
+					set(name, value)
+				}
+			case *ast.SelectorExpr: // res.Data = data
+				x, ok := lh.X.(*ast.Ident)
+				if ok && a.ctx.Package().TypesInfo.ObjectOf(x) == obj {
+					set(lh.Sel.Name, assign.Rhs[i])
+				}
+			}
+		}
+		return true
+	})
+	return values
+}
+
+func typeDataSchema(t types.Type) *common.DataSchema {
+	switch t := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsInteger != 0:
+			return &common.DataSchema{Type: common.DataTypeInteger}
+		case t.Info()&types.IsFloat != 0:
+			return &common.DataSchema{Type: common.DataTypeNumber}
+		case t.Info()&types.IsBoolean != 0:
+			return &common.DataSchema{Type: common.DataTypeBoolean}
+		case t.Info()&types.IsString != 0:
+			return &common.DataSchema{Type: common.DataTypeString}
+		}
+	case *types.Slice:
+		return &common.DataSchema{Type: common.DataTypeArray, Item: typeDataSchema(t.Elem())}
+	case *types.Array:
+		return &common.DataSchema{Type: common.DataTypeArray, Item: typeDataSchema(t.Elem())}
+	}
+	return &common.DataSchema{Type: common.DataTypeObject}
+}
@@ -0,0 +1,120 @@
+package ego
+
+import (
+	"go/ast"
+	"go/types"
+	"net/http"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/gin"
+	"github.com/gotomicro/eapi/spec"
+)
+
+const (
+	eginPackageName   = "github.com/gotomicro/ego/server/egin"
+	grpcProxyFuncName = "GRPCProxy"
+)
+
+var _ eapi.Plugin = &Plugin{}
+
+// Plugin 用于解析 ego 框架 (github.com/gotomicro/ego) 中 egin 组件的路由.
+// egin.Component 内嵌了 *gin.Engine, 所以路由声明使用 gin 插件解析. 在此基础上支持:
+//
+//   - egin.GRPCProxy(svc.Method) 形式的 handler
+//   - 内嵌了 *gin.Context 的 context wrapper (e.g. c.JSONOK(data)), 无需配置 properties
+type Plugin struct {
+	*gin.Plugin
+
+	// 已经推导过的 context wrapper 方法
+	wrapperMethods map[string]struct{}
+}
+
+func NewPlugin() *Plugin {
+	p := &Plugin{
+		Plugin:         gin.NewPlugin(),
+		wrapperMethods: make(map[string]struct{}),
+	}
+	p.Plugin.WithHandlerParser(p.parseGRPCProxy).WithHandlerParser(p.parseContextWrapper)
+	return p
+}
+
+func (p *Plugin) Name() string {
+	return "ego"
+}
+
+// parseGRPCProxy 解析 egin.GRPCProxy(svc.SayHello). svc.SayHello 的签名为 func(context.Context, *Request) (*Response, error)
+func (p *Plugin) parseGRPCProxy(ctx *eapi.Context, api *eapi.API, handlerArg ast.Expr, def *eapi.FuncDefinition) (handled bool) {
+	ctx.MatchCall(handlerArg, eapi.NewCallRule().WithRule(eginPackageName, grpcProxyFuncName), func(call *ast.CallExpr, typeName, fnName string) {
+		handled = true
+	})
+	if !handled {
+		return
+	}
+
+	fn, ok := def.Pkg().TypesInfo.Defs[def.Decl.Name].(*types.Func)
+	if !ok {
+		return
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 2 || sig.Results().Len() != 2 {
+		return
+	}
+	defCtx := ctx.NewEnv().WithPackage(def.Pkg()).WithFile(def.File())
+
+	// GRPCProxy 对 GET 请求会依次调用 c.BindUri(req) 和 c.Bind(req), 其他请求根据 Content-Type 解析 request body
+	reqTypeExpr := p.paramTypeExpr(def.Decl, 1)
+	switch api.Method {
+	case http.MethodGet:
+		if reqTypeExpr != nil {
+			params := eapi.NewParamParser(defCtx, p.paramNameParser).Parse(reqTypeExpr)
+			for _, param := range params {
+				api.Spec.AddParameter(param)
+			}
+		}
+	default:
+		contentType := eapi.MimeTypeJson
+		if len(api.Spec.Consumes) > 0 {
+			contentType = api.Spec.Consumes[0]
+		}
+		schema := defCtx.GetSchemaByType(sig.Params().At(1).Type(), contentType)
+		if schema != nil {
+			api.Spec.RequestBody = spec.NewRequestBody().WithSchemaRef(schema, []string{contentType})
+		}
+	}
+
+	res := spec.NewResponse()
+	schema := defCtx.GetSchemaByType(sig.Results().At(0).Type(), eapi.MimeTypeJson)
+	res.Content = spec.NewContentWithSchemaRef(schema, []string{eapi.MimeTypeJson})
+	api.Spec.AddResponse(http.StatusOK, res)
+	return
+}
+
+// paramTypeExpr 获取函数第 index 个参数的类型表达式
+func (p *Plugin) paramTypeExpr(decl *ast.FuncDecl, index int) ast.Expr {
+	var i int
+	for _, field := range decl.Type.Params.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		if index < i+n {
+			return field.Type
+		}
+		i += n
+	}
+	return nil
+}
+
+// paramNameParser 与 gin 的参数绑定方式保持一致: uri tag 为 path 参数, 其他为 query 参数
+func (p *Plugin) paramNameParser(fieldName string, tags map[string]string) (name, in string) {
+	if name, ok := tags["uri"]; ok {
+		name, _, _ = strings.Cut(name, ",")
+		return name, "path"
+	}
+	if name, ok := tags["form"]; ok {
+		name, _, _ = strings.Cut(name, ",")
+		return name, "query"
+	}
+	return fieldName, "query"
+}
@@ -0,0 +1,230 @@
+package fiber
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/utils"
+	"github.com/knadh/koanf"
+)
+
+var (
+	routeMethods = []string{"Get", "Head", "Post", "Put", "Patch", "Delete", "Connect", "Options", "Trace", "Add"}
+)
+
+const (
+	fiberAppTypeName    = "*github.com/gofiber/fiber/v2.App"
+	fiberGroupTypeName  = "*github.com/gofiber/fiber/v2.Group"
+	fiberRouterTypeName = "github.com/gofiber/fiber/v2.Router"
+	fiberGroupMethod    = "Group"
+	fiberAddMethod      = "Add"
+)
+
+var _ eapi.Plugin = &Plugin{}
+
+type Plugin struct {
+	config common.Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Name() string {
+	return "fiber"
+}
+
+func (p *Plugin) Mount(k *koanf.Koanf) error {
+	return k.Unmarshal("properties", &p.config)
+}
+
+func (p *Plugin) Analyze(ctx *eapi.Context, node ast.Node) {
+	switch node := node.(type) {
+	case *ast.AssignStmt:
+		p.assignStmt(ctx, node)
+	case *ast.CallExpr:
+		p.callExpr(ctx, node)
+	}
+}
+
+func (p *Plugin) assignStmt(ctx *eapi.Context, assign *ast.AssignStmt) {
+	if len(assign.Rhs) != 1 || len(assign.Lhs) != 1 {
+		return
+	}
+
+	callRule := eapi.NewCallRule().
+		WithRule(fiberAppTypeName, fiberGroupMethod).
+		WithRule(fiberGroupTypeName, fiberGroupMethod).
+		WithRule(fiberRouterTypeName, fiberGroupMethod)
+	for _, router := range p.config.RouterNames {
+		callRule = callRule.WithRule(router, fiberGroupMethod)
+	}
+
+	rh := assign.Rhs[0]
+	ctx.MatchCall(
+		rh,
+		callRule,
+		func(callExpr *ast.CallExpr, typeName, fnName string) {
+			if len(callExpr.Args) <= 0 {
+				return
+			}
+			groupPath, ok := ctx.EvalString(callExpr.Args[0])
+			if !ok {
+				fmt.Fprintf(os.Stderr, "unable to resolve route group path at %s\n", ctx.LineColumn(callExpr.Args[0].Pos()))
+				return
+			}
+			selExpr := callExpr.Fun.(*ast.SelectorExpr)
+			xIdent, ok := selExpr.X.(*ast.Ident)
+			if !ok {
+				return
+			}
+			var prefix = ""
+			v := ctx.Env.Lookup(xIdent.Name)
+			if rg, ok := v.(*eapi.RouteGroup); ok {
+				prefix = rg.Prefix
+			}
+			rg := &eapi.RouteGroup{Prefix: path.Join(prefix, p.normalizePath(groupPath))}
+			lh := assign.Lhs[0]
+			lhIdent, ok := lh.(*ast.Ident)
+			if !ok {
+				return
+			}
+			switch assign.Tok {
+			case token.ASSIGN:
+				env := ctx.Env.Resolve(lhIdent.Name)
+				if env == nil {
+					ctx.Env.Define(lhIdent.Name, rg)
+				} else {
+					env.Assign(lhIdent.Name, rg)
+				}
+
+			case token.DEFINE:
+				ctx.Env.Define(lhIdent.Name, rg)
+			}
+		},
+	)
+}
+
+func (p *Plugin) callExpr(ctx *eapi.Context, callExpr *ast.CallExpr) {
+	callRule := eapi.NewCallRule().
+		WithRule(fiberAppTypeName, routeMethods...).
+		WithRule(fiberGroupTypeName, routeMethods...).
+		WithRule(fiberRouterTypeName, routeMethods...)
+	for _, router := range p.config.RouterNames {
+		callRule = callRule.WithRule(router, routeMethods...)
+	}
+
+	ctx.MatchCall(
+		callExpr,
+		callRule,
+		func(call *ast.CallExpr, typeName, fnName string) {
+			comment := ctx.ParseComment(ctx.GetHeadingCommentOf(call.Pos()))
+			if comment.Ignore() {
+				return
+			}
+			api := p.parseAPI(ctx, callExpr, fnName, comment)
+			if api == nil {
+				return
+			}
+			ctx.AddAPI(api)
+		},
+	)
+}
+
+func (p *Plugin) parseAPI(ctx *eapi.Context, callExpr *ast.CallExpr, fnName string, comment *eapi.Comment) (api *eapi.API) {
+	args := callExpr.Args
+	method := strings.ToUpper(fnName)
+	if fnName == fiberAddMethod { // app.Add(method, path, handlers...)
+		if len(args) < 3 {
+			return
+		}
+		m, ok := ctx.EvalString(args[0])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unable to resolve route method at %s\n", ctx.LineColumn(args[0].Pos()))
+			return
+		}
+		method = strings.ToUpper(m)
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return
+	}
+	routePath, ok := ctx.EvalString(args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unable to resolve route path at %s\n", ctx.LineColumn(args[0].Pos()))
+		return
+	}
+
+	selExpr := callExpr.Fun.(*ast.SelectorExpr)
+	var prefix string
+	if xIdent, ok := selExpr.X.(*ast.Ident); ok {
+		v := ctx.Env.Lookup(xIdent.Name)
+		if rg, ok := v.(*eapi.RouteGroup); ok {
+			prefix = rg.Prefix
+		}
+	}
+
+	handlerFn := p.getHandlerFn(ctx, args)
+	if handlerFn == nil {
+		return
+	}
+	typeName, methodName := utils.GetFuncInfo(handlerFn)
+	handlerDef := ctx.GetDefinition(typeName, methodName)
+	if handlerDef == nil {
+		fmt.Fprintf(os.Stderr, "handler function %s.%s not found\n", typeName, methodName)
+		return
+	}
+	handlerFnDef, ok := handlerDef.(*eapi.FuncDefinition)
+	if !ok {
+		return
+	}
+
+	fullPath := path.Join(prefix, p.normalizePath(routePath))
+	api = eapi.NewAPI(method, fullPath)
+	api.Spec.LoadFromComment(ctx, comment)
+	api.Spec.LoadFromFuncDecl(ctx, handlerFnDef.Decl)
+	if api.Spec.OperationID == "" {
+		id := comment.ID()
+		if id == "" {
+			id = handlerFnDef.Pkg().Name + "." + handlerFnDef.Decl.Name.Name
+		}
+		api.Spec.OperationID = id
+	}
+	newHandlerAnalyzer(
+		ctx.NewEnv().WithPackage(handlerFnDef.Pkg()).WithFile(handlerFnDef.File()),
+		api,
+		handlerFnDef.Decl,
+	).WithConfig(&p.config).Parse()
+
+	return
+}
+
+// getHandlerFn handler 是最后一个参数, 前面的参数都是中间件
+func (p *Plugin) getHandlerFn(ctx *eapi.Context, args []ast.Expr) (handlerFn *types.Func) {
+	handlerArg := args[len(args)-1]
+	if call, ok := handlerArg.(*ast.CallExpr); ok {
+		nestedCall := utils.UnwrapCall(call)
+		if len(nestedCall.Args) <= 0 {
+			return
+		}
+		handlerArg = nestedCall.Args[0]
+	}
+	return ctx.GetFuncFromAstNode(handlerArg)
+}
+
+var (
+	// :id / :id? (optional parameter)
+	pathParamPattern = regexp.MustCompile(`:([^/?+*.\-]+)\??`)
+)
+
+func (p *Plugin) normalizePath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
@@ -0,0 +1,345 @@
+package fiber
+
+import (
+	"go/ast"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/spec"
+	"github.com/iancoleman/strcase"
+)
+
+const fiberContextTypeName = "*github.com/gofiber/fiber/v2.Ctx"
+
+var (
+	interestedFiberContextMethods = []string{
+		"BodyParser",
+		"QueryParser",
+		"Params",
+		"Query",
+		"FormValue",
+		"FormFile",
+		"JSON",
+		"XML",
+		"Status",
+		"SendStatus",
+		"SendString",
+		"Cookies",
+		"Cookie",
+	}
+)
+
+type handlerAnalyzer struct {
+	ctx  *eapi.Context
+	api  *eapi.API
+	spec *eapi.APISpec
+	decl *ast.FuncDecl
+
+	c *common.Config
+
+	// status code set by the latest standalone c.Status() call
+	status int
+	// c.Status() calls which have been consumed by a chained call. e.g. c.Status(400).JSON(...)
+	chainedStatus map[*ast.CallExpr]struct{}
+	// 通过 c.Cookie 设置的 cookie
+	setCookies []string
+}
+
+func newHandlerAnalyzer(ctx *eapi.Context, api *eapi.API, decl *ast.FuncDecl) *handlerAnalyzer {
+	return &handlerAnalyzer{ctx: ctx, api: api, spec: api.Spec, decl: decl, chainedStatus: make(map[*ast.CallExpr]struct{})}
+}
+
+func (p *handlerAnalyzer) WithConfig(c *common.Config) *handlerAnalyzer {
+	p.c = c
+	return p
+}
+
+func (p *handlerAnalyzer) Parse() {
+	ast.Inspect(p.decl, func(node ast.Node) bool {
+		customRuleAnalyzer := common.NewCustomRuleAnalyzer(
+			p.ctx,
+			p.spec,
+			p.api,
+			p.c,
+		)
+		matched := customRuleAnalyzer.MatchCustomResponseRule(node)
+		if matched {
+			return true
+		}
+		matched = customRuleAnalyzer.MatchCustomRequestRule(node)
+		if matched {
+			return true
+		}
+
+		p.ctx.MatchCall(node,
+			eapi.NewCallRule().WithRule(fiberContextTypeName, interestedFiberContextMethods...),
+			func(call *ast.CallExpr, typeName, fnName string) {
+				switch fnName {
+				case "BodyParser":
+					p.parseBodyParser(call)
+				case "QueryParser":
+					p.parseQueryParser(call)
+				case "Params": // path parameter
+					p.parsePrimitiveParam(call, "path")
+				case "Query": // query parameter
+					p.parsePrimitiveParam(call, "query")
+				case "FormValue":
+					p.parseFormData(call, "string")
+				case "FormFile":
+					p.parseFormData(call, "file")
+				case "JSON":
+					p.parseResBody(call, eapi.MimeTypeJson)
+				case "XML":
+					p.parseResBody(call, eapi.MimeApplicationXml)
+				case "SendString":
+					p.parseSendString(call)
+				case "Status":
+					p.parseStatus(call)
+				case "SendStatus":
+					p.parseSendStatus(call)
+				case "Cookies": // cookie parameter
+					p.parsePrimitiveParam(call, "cookie")
+				case "Cookie":
+					p.parseSetCookie(call)
+				}
+			},
+		)
+		return true
+	})
+	common.AddSetCookieHeader(p.spec, p.setCookies)
+}
+
+// parseSetCookie 解析 c.Cookie(&fiber.Cookie{Name: "session"})
+func (p *handlerAnalyzer) parseSetCookie(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	if name, ok := common.CookieName(p.ctx, call.Args[0]); ok {
+		p.setCookies = append(p.setCookies, name)
+	}
+}
+
+func (p *handlerAnalyzer) paramNameParser(fieldName string, tags map[string]string) (name, in string) {
+	name, ok := tags["query"]
+	if ok {
+		name, _, _ = strings.Cut(name, ",")
+		return name, "query"
+	}
+	return fieldName, "query"
+}
+
+// parseBodyParser 解析 c.BodyParser(&req)
+func (p *handlerAnalyzer) parseBodyParser(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	arg0 := call.Args[0]
+
+	contentType := p.getDefaultContentType()
+	schema := p.ctx.GetSchemaByExpr(arg0, contentType)
+	if schema == nil {
+		return
+	}
+	commentGroup := p.ctx.GetHeadingCommentOf(call.Pos())
+	if commentGroup != nil {
+		comment := p.ctx.ParseComment(commentGroup)
+		schema.Description = comment.Text()
+	}
+	reqBody := spec.NewRequestBody().WithSchemaRef(schema, []string{contentType})
+	p.spec.RequestBody = reqBody
+}
+
+// parseQueryParser 解析 c.QueryParser(&params)
+func (p *handlerAnalyzer) parseQueryParser(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	params := eapi.NewParamParser(p.ctx, p.paramNameParser).Parse(call.Args[0])
+	for _, param := range params {
+		p.spec.AddParameter(param)
+	}
+}
+
+// statusOf 获取响应的状态码. 支持 c.Status(400).JSON(...) 和 c.Status(400); c.JSON(...) 两种写法
+func (p *handlerAnalyzer) statusOf(call *ast.CallExpr) int {
+	if selExpr, ok := call.Fun.(*ast.SelectorExpr); ok {
+		if statusCall, ok := selExpr.X.(*ast.CallExpr); ok {
+			_, fnName, err := p.ctx.GetCallInfo(statusCall)
+			if err == nil && fnName == "Status" && len(statusCall.Args) == 1 {
+				p.chainedStatus[statusCall] = struct{}{}
+				return p.ctx.ParseStatusCode(statusCall.Args[0])
+			}
+		}
+	}
+
+	status := p.status
+	p.status = 0
+	if status == 0 {
+		return http.StatusOK
+	}
+	return status
+}
+
+func (p *handlerAnalyzer) parseStatus(call *ast.CallExpr) {
+	if _, ok := p.chainedStatus[call]; ok {
+		return
+	}
+	if len(call.Args) != 1 {
+		return
+	}
+	p.status = p.ctx.ParseStatusCode(call.Args[0])
+}
+
+// parseSendStatus 解析 c.SendStatus(http.StatusNoContent)
+func (p *handlerAnalyzer) parseSendStatus(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	p.spec.AddResponse(p.ctx.ParseStatusCode(call.Args[0]), res)
+}
+
+func (p *handlerAnalyzer) parseResBody(call *ast.CallExpr, contentType string) {
+	if len(call.Args) < 1 {
+		return
+	}
+
+	res := spec.NewResponse()
+	commentGroup := p.ctx.GetHeadingCommentOf(call.Pos())
+	if commentGroup != nil {
+		comment := p.ctx.ParseComment(commentGroup)
+		res.Description = comment.TextPointer()
+	}
+
+	schema := p.ctx.GetSchemaByExpr(call.Args[0], contentType)
+	res.Content = spec.NewContentWithSchemaRef(schema, []string{contentType})
+	p.spec.AddResponse(p.statusOf(call), res)
+}
+
+// parseSendString 解析 c.SendString("...")
+func (p *handlerAnalyzer) parseSendString(call *ast.CallExpr) {
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	res.Content = spec.NewContentWithSchema(spec.NewStringSchema(), []string{"text/plain"})
+	p.spec.AddResponse(p.statusOf(call), res)
+}
+
+func (p *handlerAnalyzer) parsePrimitiveParam(call *ast.CallExpr, in string) {
+	param := p.primitiveParam(call, in)
+	if param == nil {
+		return
+	}
+	p.spec.AddParameter(param)
+}
+
+func (p *handlerAnalyzer) parseFormData(call *ast.CallExpr, fieldType string, options ...func(s *spec.Schema)) {
+	if len(call.Args) <= 0 {
+		return
+	}
+	arg0 := call.Args[0]
+	arg0Lit, ok := arg0.(*ast.BasicLit)
+	if !ok {
+		return
+	}
+
+	name := strings.Trim(arg0Lit.Value, "\"")
+	paramSchema := spec.NewSchema()
+	paramSchema.Title = name
+	paramSchema.Type = fieldType
+	for _, option := range options {
+		option(paramSchema)
+	}
+
+	requestBody := p.spec.RequestBody
+	if requestBody == nil {
+		requestBody = spec.NewRequestBody().WithContent(spec.NewContent())
+		p.spec.RequestBody = requestBody
+	}
+	mediaType := requestBody.GetMediaType(eapi.MimeTypeFormData)
+	if mediaType == nil {
+		mediaType = spec.NewMediaType()
+		requestBody.Content[eapi.MimeTypeFormData] = mediaType
+	}
+
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	paramSchema.Description = comment.Text()
+
+	var schemaRef = mediaType.Schema
+	var schema *spec.SchemaRef
+	if schemaRef != nil {
+		schema = spec.Unref(p.ctx.Doc(), schemaRef)
+		schema.WithProperty(name, paramSchema)
+	} else {
+		schema = spec.NewObjectSchema().NewRef()
+		title := strcase.ToCamel(p.spec.OperationID) + "Request"
+		schema.Title = title
+		schema.WithProperty(name, paramSchema)
+		p.ctx.Doc().Components.Schemas[title] = schema
+		schemaRef = spec.RefComponentSchemas(title)
+		mediaType.Schema = schemaRef
+	}
+	if comment.Required() {
+		schema.Required = append(schema.Required, name)
+	}
+}
+
+func (p *handlerAnalyzer) primitiveParam(call *ast.CallExpr, in string) *spec.Parameter {
+	if len(call.Args) <= 0 {
+		return nil
+	}
+	arg0 := call.Args[0]
+	arg0Lit, ok := arg0.(*ast.BasicLit)
+	if !ok {
+		return nil
+	}
+	name := strings.Trim(arg0Lit.Value, "\"")
+	paramSchema := spec.NewSchema()
+	paramSchema.Title = name
+	paramSchema.Type = "string"
+	// c.Query("name", "defaultValue")
+	if len(call.Args) > 1 {
+		if arg1Lit, ok := call.Args[1].(*ast.BasicLit); ok {
+			paramSchema.Default, _ = strconv.Unquote(arg1Lit.Value)
+		}
+	}
+
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+
+	var res *spec.Parameter
+	switch in {
+	case "path":
+		res = spec.NewPathParameter(name).WithSchema(paramSchema)
+	case "query":
+		res = spec.NewQueryParameter(name).WithSchema(paramSchema)
+		res.Required = comment.Required()
+	case "cookie":
+		res = spec.NewCookieParameter(name).WithSchema(paramSchema)
+		res.Required = comment.Required()
+	default:
+		return nil
+	}
+
+	res.Description = comment.Text()
+	return res
+}
+
+// 获取一个尽可能正确的 request payload contentType
+func (p *handlerAnalyzer) getDefaultContentType() string {
+	if len(p.spec.Consumes) != 0 {
+		return p.spec.Consumes[0]
+	}
+
+	// fallback
+	switch p.api.Method {
+	case http.MethodGet, http.MethodHead:
+		return eapi.MimeTypeFormData
+	default:
+		return eapi.MimeTypeJson
+	}
+}
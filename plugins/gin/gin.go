@@ -21,22 +21,42 @@ var (
 )
 
 const (
+	ginEngineTypeName      = "*github.com/gin-gonic/gin.Engine"
 	ginRouterGroupTypeName = "*github.com/gin-gonic/gin.RouterGroup"
 	ginIRouterTypeName     = "github.com/gin-gonic/gin.IRouter"
 	ginIRoutesTypeName     = "github.com/gin-gonic/gin.IRoutes"
 	routerGroupMethodName  = "Group"
+	routerHandleMethodName = "Handle"
+	routerAnyMethodName    = "Any"
+	routerUseMethodName    = "Use"
 )
 
 var _ analyzer.Plugin = &Plugin{}
 
+// HandlerParser 用于扩展 handler 函数的解析. handlerArg 为路由声明中的 handler 参数, def 为 handler 对应的函数定义.
+// 返回 true 表示已经完成解析, 不再使用默认的方式解析 handler 函数
+type HandlerParser func(ctx *analyzer.Context, api *analyzer.API, handlerArg ast.Expr, def *analyzer.FuncDefinition) bool
+
 type Plugin struct {
-	config common.Config
+	config         common.Config
+	handlerParsers []HandlerParser
 }
 
 func NewPlugin() *Plugin {
 	return &Plugin{}
 }
 
+// WithHandlerParser 添加 handler 解析扩展. 用于基于 gin 的框架插件 (e.g. ego)
+func (e *Plugin) WithHandlerParser(parser HandlerParser) *Plugin {
+	e.handlerParsers = append(e.handlerParsers, parser)
+	return e
+}
+
+// Config 返回插件配置. 基于 gin 的框架插件可以通过它追加自定义规则
+func (e *Plugin) Config() *common.Config {
+	return &e.config
+}
+
 func (e *Plugin) Mount(k *koanf.Koanf) error {
 	err := k.Unmarshal("properties", &e.config)
 	if err != nil {
@@ -52,6 +72,10 @@ func (e *Plugin) Analyze(ctx *analyzer.Context, node ast.Node) {
 		e.assignStmt(ctx, n)
 	case *ast.CallExpr:
 		e.callExpr(ctx, n)
+		e.useExpr(ctx, n)
+		common.PropagateRouteGroups(ctx, n, func(expr ast.Expr) *analyzer.RouteGroup { return e.routeGroupOf(ctx, expr) })
+	case *ast.CompositeLit:
+		common.BindRouteGroupFields(ctx, n, func(expr ast.Expr) *analyzer.RouteGroup { return e.routeGroupOf(ctx, expr) })
 	}
 }
 
@@ -64,69 +88,120 @@ func (e *Plugin) assignStmt(ctx *analyzer.Context, node ast.Node) {
 	if len(assign.Rhs) != 1 || len(assign.Lhs) != 1 {
 		return
 	}
-
-	callRule := analyzer.NewCallRule().
-		WithRule(ginRouterGroupTypeName, routerGroupMethodName).
-		WithRule(ginIRouterTypeName, routerGroupMethodName).
-		WithRule(ginIRoutesTypeName, routerGroupMethodName)
-	for _, router := range e.config.RouterNames {
-		callRule = callRule.WithRule(router, routerGroupMethodName)
+	rg := e.routeGroupOf(ctx, assign.Rhs[0])
+	if rg == nil {
+		return
 	}
 
-	rh := assign.Rhs[0]
-	ctx.MatchCall(
-		rh,
-		callRule,
-		func(callExpr *ast.CallExpr, typeName, fnName string) {
-			if len(callExpr.Args) <= 0 {
-				return
+	switch lh := assign.Lhs[0].(type) {
+	case *ast.Ident:
+		switch assign.Tok {
+		case token.ASSIGN:
+			env := ctx.Env.Resolve(lh.Name)
+			if env == nil {
+				ctx.Env.Define(lh.Name, rg)
+			} else {
+				env.Assign(lh.Name, rg)
 			}
-			arg0, ok := callExpr.Args[0].(*ast.BasicLit)
-			if !ok {
+
+		case token.DEFINE:
+			ctx.Env.Define(lh.Name, rg)
+		}
+	case *ast.SelectorExpr: // h.router = g
+		obj := ctx.Package().TypesInfo.ObjectOf(lh.Sel)
+		if obj == nil {
+			return
+		}
+		env := ctx.Env.Resolve(obj)
+		if env == nil {
+			ctx.Env.Define(obj, rg)
+		} else {
+			env.Assign(obj, rg)
+		}
+	}
+}
+
+// routeGroupOf 获取表达式对应的路由分组. e.g. g / h.router / r.Group("/api"). 未知的路由分组返回 nil
+func (e *Plugin) routeGroupOf(ctx *analyzer.Context, expr ast.Expr) *analyzer.RouteGroup {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return e.routeGroupOf(ctx, expr.X)
+	case *ast.Ident:
+		rg, _ := ctx.Env.Lookup(expr.Name).(*analyzer.RouteGroup)
+		return rg
+	case *ast.SelectorExpr:
+		obj := ctx.Package().TypesInfo.ObjectOf(expr.Sel)
+		if obj == nil {
+			return nil
+		}
+		rg, _ := ctx.Env.Lookup(obj).(*analyzer.RouteGroup)
+		return rg
+	case *ast.CallExpr:
+		callRule := analyzer.NewCallRule().
+			WithRule(ginRouterGroupTypeName, routerGroupMethodName).
+			WithRule(ginIRouterTypeName, routerGroupMethodName).
+			WithRule(ginIRoutesTypeName, routerGroupMethodName)
+		for _, router := range e.config.RouterNames {
+			callRule = callRule.WithRule(router, routerGroupMethodName)
+		}
+
+		var res *analyzer.RouteGroup
+		ctx.MatchCall(expr, callRule, func(call *ast.CallExpr, typeName, fnName string) {
+			if len(call.Args) <= 0 {
 				return
 			}
-			selExpr := callExpr.Fun.(*ast.SelectorExpr)
-			xIdent, ok := selExpr.X.(*ast.Ident)
+			groupPath, ok := ctx.EvalString(call.Args[0])
 			if !ok {
+				fmt.Fprintf(os.Stderr, "unable to resolve route group path at %s\n", ctx.LineColumn(call.Args[0].Pos()))
 				return
 			}
-			var prefix = ""
-			v := ctx.Env.Lookup(xIdent.Name)
-			if rg, ok := v.(*analyzer.RouteGroup); ok {
-				prefix = rg.Prefix
+			var prefix string
+			parent := e.routeGroupOf(ctx, call.Fun.(*ast.SelectorExpr).X)
+			if parent != nil {
+				prefix = parent.Prefix
 			}
+			// r.Group(path, middlewares...)
+			res = analyzer.NewRouteGroup(parent, path.Join(prefix, e.normalizePath(groupPath)))
+			res.Use(common.ResolveMiddlewares(ctx, call.Args[1:])...)
+		})
+		return res
+	}
+	return nil
+}
+
+// useExpr 记录通过 r.Use(middlewares...) 添加的中间件
+func (e *Plugin) useExpr(ctx *analyzer.Context, callExpr *ast.CallExpr) {
+	callRule := analyzer.NewCallRule().WithRule(ginEngineTypeName, routerUseMethodName).
+		WithRule(ginRouterGroupTypeName, routerUseMethodName).
+		WithRule(ginIRouterTypeName, routerUseMethodName).
+		WithRule(ginIRoutesTypeName, routerUseMethodName)
+	for _, router := range e.config.RouterNames {
+		callRule = callRule.WithRule(router, routerUseMethodName)
+	}
 
-			rg := &analyzer.RouteGroup{Prefix: path.Join(prefix, e.normalizePath(strings.Trim(arg0.Value, "\"")))}
-			lh := assign.Lhs[0]
-			lhIdent, ok := lh.(*ast.Ident)
+	ctx.MatchCall(callExpr, callRule, func(call *ast.CallExpr, typeName, fnName string) {
+		x := call.Fun.(*ast.SelectorExpr).X
+		rg := e.routeGroupOf(ctx, x)
+		if rg == nil {
+			// 根路由. e.g. r := gin.Default(); r.Use(...)
+			ident, ok := x.(*ast.Ident)
 			if !ok {
 				return
 			}
-
-			switch assign.Tok {
-			case token.ASSIGN:
-				env := ctx.Env.Resolve(lhIdent.Name)
-				if env == nil {
-					ctx.Env.Define(lhIdent.Name, rg)
-				} else {
-					env.Assign(lhIdent.Name, rg)
-				}
-
-			case token.DEFINE:
-				ctx.Env.Define(lhIdent.Name, rg)
-			}
-		},
-	)
-
-	return
+			rg = analyzer.NewRouteGroup(nil, "")
+			ctx.Env.Define(ident.Name, rg)
+		}
+		rg.Use(common.ResolveMiddlewares(ctx, call.Args)...)
+	})
 }
 
 func (e *Plugin) callExpr(ctx *analyzer.Context, callExpr *ast.CallExpr) {
-	callRule := analyzer.NewCallRule().WithRule(ginRouterGroupTypeName, routeMethods...).
-		WithRule(ginIRouterTypeName, routeMethods...).
-		WithRule(ginIRoutesTypeName, routeMethods...)
+	fnNames := append([]string{routerHandleMethodName, routerAnyMethodName}, routeMethods...)
+	callRule := analyzer.NewCallRule().WithRule(ginRouterGroupTypeName, fnNames...).
+		WithRule(ginIRouterTypeName, fnNames...).
+		WithRule(ginIRoutesTypeName, fnNames...)
 	for _, router := range e.config.RouterNames {
-		callRule = callRule.WithRule(router, routeMethods...)
+		callRule = callRule.WithRule(router, fnNames...)
 	}
 
 	ctx.MatchCall(
@@ -137,38 +212,65 @@ func (e *Plugin) callExpr(ctx *analyzer.Context, callExpr *ast.CallExpr) {
 			if comment.Ignore() {
 				return
 			}
-			api := e.parseAPI(ctx, callExpr, comment)
-			if api == nil {
-				return
+
+			var methods []string
+			args := callExpr.Args
+			switch fnName {
+			case routerHandleMethodName: // r.Handle(method, path, handlers...)
+				if len(args) < 3 {
+					return
+				}
+				method, ok := ctx.EvalString(args[0])
+				if !ok {
+					fmt.Fprintf(os.Stderr, "unable to resolve route method at %s\n", ctx.LineColumn(args[0].Pos()))
+					return
+				}
+				methods, args = []string{strings.ToUpper(method)}, args[1:]
+			case routerAnyMethodName: // r.Any(path, handlers...)
+				methods = routeMethods
+			default:
+				methods = []string{fnName}
+			}
+
+			for _, method := range methods {
+				api := e.parseAPI(ctx, callExpr, method, args, comment)
+				if api == nil {
+					continue
+				}
+				if fnName == routerAnyMethodName {
+					api.Spec.OperationID += "_" + strings.ToLower(method)
+				}
+				ctx.AddAPI(api)
 			}
-			ctx.AddAPI(api)
 		},
 	)
 }
 
-func (e *Plugin) parseAPI(ctx *analyzer.Context, callExpr *ast.CallExpr, comment *analyzer.Comment) (api *analyzer.API) {
-	if len(callExpr.Args) < 2 {
+// parseAPI 解析路由声明. args 为 path 及之后的参数
+func (e *Plugin) parseAPI(ctx *analyzer.Context, callExpr *ast.CallExpr, method string, args []ast.Expr, comment *analyzer.Comment) (api *analyzer.API) {
+	if len(args) < 2 {
 		return
 	}
-	arg0, ok := callExpr.Args[0].(*ast.BasicLit)
+	routePath, ok := ctx.EvalString(args[0])
 	if !ok {
+		fmt.Fprintf(os.Stderr, "unable to resolve route path at %s\n", ctx.LineColumn(args[0].Pos()))
 		return
 	}
 
-	selExpr := callExpr.Fun.(*ast.SelectorExpr)
 	var prefix string
-	if xIdent, ok := selExpr.X.(*ast.Ident); ok {
-		v := ctx.Env.Lookup(xIdent.Name)
-		if rg, ok := v.(*analyzer.RouteGroup); ok {
-			prefix = rg.Prefix
-		}
+	var middlewares []*analyzer.FuncDefinition
+	if rg := e.routeGroupOf(ctx, callExpr.Fun.(*ast.SelectorExpr).X); rg != nil {
+		prefix, middlewares = rg.Prefix, rg.Middlewares
 	}
+	// r.GET(path, middlewares..., handler)
+	middlewares = append(append([]*analyzer.FuncDefinition{}, middlewares...), common.ResolveMiddlewares(ctx, args[1:len(args)-1])...)
 
-	handlerFn := e.getHandlerFn(ctx, callExpr)
-	if handlerFn == nil {
+	handlerArg := ctx.ResolveExpr(args[len(args)-1])
+	handler := common.ResolveHandler(ctx, handlerArg, e.isHandler)
+	if handler == nil {
 		return
 	}
-	typeName, methodName := utils.GetFuncInfo(handlerFn)
+	typeName, methodName := utils.GetFuncInfo(handler.Fn)
 	handlerDef := ctx.GetDefinition(typeName, methodName)
 	if handlerDef == nil {
 		fmt.Fprintf(os.Stderr, "handler function %s.%s not found\n", typeName, methodName)
@@ -179,8 +281,7 @@ func (e *Plugin) parseAPI(ctx *analyzer.Context, callExpr *ast.CallExpr, comment
 		return
 	}
 
-	fullPath := path.Join(prefix, e.normalizePath(strings.Trim(arg0.Value, "\"")))
-	method := selExpr.Sel.Name
+	fullPath := path.Join(prefix, e.normalizePath(routePath))
 	api = analyzer.NewAPI(method, fullPath)
 	api.Spec.LoadFromComment(ctx, comment)
 	api.Spec.LoadFromFuncDecl(ctx, handlerFnDef.Decl)
@@ -191,24 +292,33 @@ func (e *Plugin) parseAPI(ctx *analyzer.Context, callExpr *ast.CallExpr, comment
 		}
 		api.Spec.OperationID = id
 	}
-	newHandlerParser(
+	defer common.ParseMiddlewares(ctx, api, middlewares, e.parseMiddleware)
+	for _, parser := range e.handlerParsers {
+		if parser(ctx, api, handlerArg, handlerFnDef) {
+			return
+		}
+	}
+	parser := newHandlerParser(
 		ctx.NewEnv().WithPackage(handlerFnDef.Pkg()).WithFile(handlerFnDef.File()),
 		api,
 		handlerFnDef.Decl,
-	).WithConfig(&e.config).Parse()
+	).WithConfig(&e.config)
+	if handler.IsAdapter() {
+		handler.ParseAdapter(ctx, api, parser.paramNameParser)
+		return
+	}
+	parser.Parse()
 	return
 }
 
-func (e *Plugin) getHandlerFn(ctx *analyzer.Context, callExpr *ast.CallExpr) (handlerFn *types.Func) {
-	handlerArg := callExpr.Args[len(callExpr.Args)-1]
-	if call, ok := handlerArg.(*ast.CallExpr); ok {
-		nestedCall := utils.UnwrapCall(call)
-		if len(nestedCall.Args) <= 0 {
-			return
-		}
-		handlerArg = nestedCall.Args[0]
-	}
-	return ctx.GetFuncFromAstNode(handlerArg)
+// parseMiddleware 和 handler 函数一样解析中间件函数
+func (e *Plugin) parseMiddleware(ctx *analyzer.Context, api *analyzer.API, def *analyzer.FuncDefinition) {
+	newHandlerParser(ctx, api, def.Decl).WithConfig(&e.config).Parse()
+}
+
+// isHandler 判断是否是 gin.HandlerFunc
+func (e *Plugin) isHandler(sig *types.Signature) bool {
+	return sig.Params().Len() == 1 && sig.Results().Len() == 0 && sig.Params().At(0).Type().String() == ginContextIdentName
 }
 
 var (
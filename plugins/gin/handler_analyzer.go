@@ -29,10 +29,29 @@ var (
 		"ShouldBindXML",
 		"ShouldBindYAML",
 		"ShouldBindTOML",
+		"BindHeader",
 		"ShouldBindUri",
 		"ShouldBindHeader",
 		"JSON",
+		"IndentedJSON",
+		"SecureJSON",
+		"PureJSON",
+		"AsciiJSON",
+		"AbortWithStatusJSON",
+		"AbortWithStatusPureJSON",
+		"AbortWithStatus",
+		"AbortWithError",
+		"Status",
+		"String",
+		"HTML",
+		"Data",
+		"File",
+		"FileAttachment",
+		"YAML",
 		"Query",
+		"GetHeader",
+		"Cookie",
+		"SetCookie",
 		"Param",
 		"PostForm",
 		"PostFormArray",
@@ -53,6 +72,9 @@ type handlerAnalyzer struct {
 	decl *ast.FuncDecl
 
 	c *common.Config
+
+	// 通过 c.SetCookie 设置的 cookie
+	setCookies []string
 }
 
 func newHandlerParser(ctx *analyzer.Context, api *analyzer.API, decl *ast.FuncDecl) *handlerAnalyzer {
@@ -65,6 +87,11 @@ func (p *handlerAnalyzer) WithConfig(c *common.Config) *handlerAnalyzer {
 }
 
 func (p *handlerAnalyzer) Parse() {
+	p.parseBody()
+	common.AddSetCookieHeader(p.spec, p.setCookies)
+}
+
+func (p *handlerAnalyzer) parseBody() {
 	ast.Inspect(p.decl, func(node ast.Node) bool {
 		customRuleAnalyzer := common.NewCustomRuleAnalyzer(
 			p.ctx,
@@ -98,15 +125,33 @@ func (p *handlerAnalyzer) Parse() {
 				case "BindUri", "ShouldBindUri":
 					p.parseBindUri(call)
 				case "BindHeader", "ShouldBindHeader":
-					// TODO
-				case "JSON":
+					p.parseBindHeader(call)
+				case "JSON", "IndentedJSON", "SecureJSON", "PureJSON", "AsciiJSON", "AbortWithStatusJSON", "AbortWithStatusPureJSON":
 					p.parseResBody(call, analyzer.MimeTypeJson)
+				case "YAML":
+					p.parseResBody(call, "application/yaml")
+				case "Status", "AbortWithStatus", "AbortWithError":
+					p.parseStatusRes(call)
+				case "String": // c.String(code, format, values...)
+					p.parseRawRes(call, call.Args[0], "text/plain", spec.NewStringSchema())
+				case "HTML": // c.HTML(code, name, obj)
+					p.parseRawRes(call, call.Args[0], "text/html", spec.NewStringSchema())
+				case "Data": // c.Data(code, contentType, data)
+					p.parseDataRes(call)
+				case "File", "FileAttachment":
+					p.parseRawRes(call, nil, "application/octet-stream", spec.NewStringSchema().WithFormat("binary"))
 				case "XML":
 					p.parseResBody(call, analyzer.MimeApplicationXml)
 				case "Query": // query parameter
 					p.parsePrimitiveParam(call, "query")
 				case "Param": // path parameter
 					p.parsePrimitiveParam(call, "path")
+				case "GetHeader": // header parameter
+					p.parsePrimitiveParam(call, "header")
+				case "Cookie": // cookie parameter
+					p.parsePrimitiveParam(call, "cookie")
+				case "SetCookie":
+					p.parseSetCookie(call)
 				case "PostForm", "GetPostForm":
 					p.parseFormData(call, "string")
 				case "FormFile":
@@ -125,15 +170,39 @@ func (p *handlerAnalyzer) Parse() {
 					p.parsePrimitiveParamWithDefault(call, "query")
 				case "DefaultPostForm":
 					p.parseFormData(call, "string")
-					// TODO: supporting more methods (FileForm(), HTML(), Data(), etc...)
 				}
 			},
 		)
+		common.MatchRequestHeaderGet(p.ctx, node, func(call *ast.CallExpr) { // c.Request.Header.Get("X-Foo")
+			p.parsePrimitiveParam(call, "header")
+		})
+		common.MatchRequestCookie(p.ctx, node, func(call *ast.CallExpr) { // c.Request.Cookie("session")
+			p.parsePrimitiveParam(call, "cookie")
+		})
+		common.MatchHelperCall(p.ctx, node, ginContextIdentName, func(ctx *analyzer.Context, def *analyzer.FuncDefinition) { // resp.OK(c, data)
+			p.parseHelper(ctx, def)
+		})
 		return true
 	})
 }
 
+// parseHelper 解析 handler 调用的 helper 函数中的参数和响应
+func (p *handlerAnalyzer) parseHelper(ctx *analyzer.Context, def *analyzer.FuncDefinition) {
+	helper := newHandlerParser(ctx, p.api, def.Decl).WithConfig(p.c)
+	helper.parseBody()
+	p.setCookies = append(p.setCookies, helper.setCookies...)
+}
+
+// paramNameParser 根据 uri/header/form tag 解析参数名和参数位置
 func (p *handlerAnalyzer) paramNameParser(fieldName string, tags map[string]string) (name, in string) {
+	if name, ok := tags["uri"]; ok {
+		name, _, _ = strings.Cut(name, ",")
+		return name, "path"
+	}
+	if name, ok := tags["header"]; ok {
+		name, _, _ = strings.Cut(name, ",")
+		return name, "header"
+	}
 	name, ok := tags["form"]
 	if ok {
 		name, _, _ = strings.Cut(name, ",")
@@ -142,6 +211,38 @@ func (p *handlerAnalyzer) paramNameParser(fieldName string, tags map[string]stri
 	return fieldName, "query"
 }
 
+// bodyParamNameParser 用于拆分请求 body 对应的结构体. 带有 uri/header tag 的字段作为 path/header 参数;
+// body 不是表单时, 只带有 form tag (没有 json/xml 等 tag) 的字段作为 query 参数. 其余字段属于 body, 返回的 in 为空.
+// 作为参数的字段在 body 中的属性名会被记录到 excluded 中
+func (p *handlerAnalyzer) bodyParamNameParser(contentType string, excluded *[]string) analyzer.ParamNameParser {
+	bodyTag := "json"
+	switch contentType {
+	case analyzer.MimeApplicationXml, analyzer.MimeTypeXml:
+		bodyTag = "xml"
+	case analyzer.MimeTypeFormData, analyzer.MimeTypeFormUrlencoded:
+		bodyTag = "form"
+	}
+
+	return func(fieldName string, tags map[string]string) (name, in string) {
+		name, in = p.paramNameParser(fieldName, tags)
+		_, hasBodyTag := tags[bodyTag]
+		_, hasForm := tags["form"]
+		switch {
+		case in == "path" || in == "header":
+		case in == "query" && hasForm && !hasBodyTag:
+		default:
+			return fieldName, ""
+		}
+
+		propName := fieldName
+		if value := tags[bodyTag]; value != "" {
+			propName, _, _ = strings.Cut(value, ",")
+		}
+		*excluded = append(*excluded, propName)
+		return
+	}
+}
+
 func (p *handlerAnalyzer) parseBinding(call *ast.CallExpr) {
 	if len(call.Args) != 1 {
 		return
@@ -160,16 +261,40 @@ func (p *handlerAnalyzer) parseBinding(call *ast.CallExpr) {
 	}
 }
 
+func (p *handlerAnalyzer) parseBindHeader(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	params := analyzer.NewParamParser(p.ctx, common.HeaderNameParser).Parse(call.Args[0])
+	for _, param := range params {
+		p.spec.AddParameter(param)
+	}
+}
+
 func (p *handlerAnalyzer) parseBindWithContentType(call *ast.CallExpr, contentType string) {
 	if len(call.Args) != 1 {
 		return
 	}
 	arg0 := call.Args[0]
 
+	var excluded []string
+	params := analyzer.NewParamParser(p.ctx, p.bodyParamNameParser(contentType, &excluded)).Parse(arg0)
+	for _, param := range params {
+		if param.In != "" {
+			p.spec.AddParameter(param)
+		}
+	}
+
 	schema := p.ctx.GetSchemaByExpr(arg0, contentType)
 	if schema == nil {
 		return
 	}
+	if len(excluded) > 0 {
+		schema = p.bodySchema(schema, excluded)
+		if schema == nil {
+			return
+		}
+	}
 	commentGroup := p.ctx.GetHeadingCommentOf(call.Pos())
 	if commentGroup != nil {
 		comment := p.ctx.ParseComment(commentGroup)
@@ -179,6 +304,31 @@ func (p *handlerAnalyzer) parseBindWithContentType(call *ast.CallExpr, contentTy
 	p.spec.RequestBody = reqBody
 }
 
+// bodySchema 从请求结构体的 schema 中移除作为参数的属性, 得到请求 body 的 schema. 移除之后没有属性时返回 nil
+func (p *handlerAnalyzer) bodySchema(schemaRef *spec.SchemaRef, excluded []string) *spec.SchemaRef {
+	schema := spec.Unref(p.ctx.Doc(), schemaRef)
+	if schema == nil || schema.Properties == nil {
+		return schemaRef
+	}
+
+	body := schema.Clone()
+	for _, name := range excluded {
+		delete(body.Properties, name)
+	}
+	body.Required = lo.Without(body.Required, excluded...)
+	if len(body.Properties) == 0 {
+		return nil
+	}
+	if schemaRef.Ref == "" {
+		return body
+	}
+
+	key := strings.TrimPrefix(schemaRef.Ref, "#/components/schemas/") + "Body"
+	body.Title += "Body"
+	p.ctx.Doc().Components.Schemas[key] = body
+	return spec.RefComponentSchemas(key)
+}
+
 func (p *handlerAnalyzer) parseResBody(call *ast.CallExpr, contentType string) {
 	if len(call.Args) != 2 {
 		return
@@ -197,6 +347,58 @@ func (p *handlerAnalyzer) parseResBody(call *ast.CallExpr, contentType string) {
 	p.spec.AddResponse(statusCode, res)
 }
 
+// parseSetCookie 解析 c.SetCookie(name, value, maxAge, path, domain, secure, httpOnly)
+func (p *handlerAnalyzer) parseSetCookie(call *ast.CallExpr) {
+	if len(call.Args) == 0 {
+		return
+	}
+	if name, ok := p.ctx.EvalString(call.Args[0]); ok {
+		p.setCookies = append(p.setCookies, name)
+	}
+}
+
+// parseStatusRes 解析没有响应 body 的调用. e.g. c.Status(http.StatusNoContent) / c.AbortWithStatus(http.StatusUnauthorized)
+func (p *handlerAnalyzer) parseStatusRes(call *ast.CallExpr) {
+	if len(call.Args) == 0 {
+		return
+	}
+	statusCode := p.ctx.ParseStatusCode(call.Args[0])
+	if p.spec.Responses.Get(statusCode) != nil { // e.g. c.Status(http.StatusCreated); c.JSON(http.StatusCreated, res)
+		return
+	}
+
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	p.spec.AddResponse(statusCode, res)
+}
+
+// parseRawRes 解析指定 content-type 的响应. status 为空时状态码为 200. e.g. c.String() / c.HTML() / c.File()
+func (p *handlerAnalyzer) parseRawRes(call *ast.CallExpr, status ast.Expr, contentType string, schema *spec.Schema) {
+	statusCode := http.StatusOK
+	if status != nil {
+		statusCode = p.ctx.ParseStatusCode(status)
+	}
+
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	res.Content = spec.NewContentWithSchema(schema, []string{contentType})
+	p.spec.AddResponse(statusCode, res)
+}
+
+// parseDataRes 解析 c.Data(code, contentType, data). 无法确定 contentType 时使用 application/octet-stream
+func (p *handlerAnalyzer) parseDataRes(call *ast.CallExpr) {
+	if len(call.Args) != 3 {
+		return
+	}
+	contentType, ok := p.ctx.EvalString(call.Args[1])
+	if !ok {
+		contentType = "application/octet-stream"
+	}
+	p.parseRawRes(call, call.Args[0], contentType, spec.NewStringSchema().WithFormat("binary"))
+}
+
 func (p *handlerAnalyzer) parseRedirectRes(call *ast.CallExpr) {
 	if len(call.Args) == 0 {
 		return
@@ -217,6 +419,9 @@ func (p *handlerAnalyzer) parseRedirectRes(call *ast.CallExpr) {
 
 func (p *handlerAnalyzer) parsePrimitiveParam(call *ast.CallExpr, in string) {
 	param := p.primitiveParam(call, in)
+	if param == nil {
+		return
+	}
 	p.spec.AddParameter(param)
 }
 
@@ -317,6 +522,12 @@ func (p *handlerAnalyzer) primitiveParam(call *ast.CallExpr, in string) *spec.Pa
 	case "query":
 		res = spec.NewQueryParameter(name).WithSchema(paramSchema)
 		res.Required = comment.Required()
+	case "header":
+		res = spec.NewHeaderParameter(name).WithSchema(paramSchema)
+		res.Required = comment.Required()
+	case "cookie":
+		res = spec.NewCookieParameter(name).WithSchema(paramSchema)
+		res.Required = comment.Required()
 	default:
 		return nil
 	}
@@ -0,0 +1,315 @@
+package grpcgateway
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/spec"
+	"github.com/gotomicro/eapi/tag"
+	"github.com/knadh/koanf"
+	"github.com/samber/lo"
+)
+
+const (
+	runtimePackageName = "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	serveMuxTypeName   = "*" + runtimePackageName + ".ServeMux"
+	marshalerTypeName  = runtimePackageName + ".Marshaler"
+
+	registerFuncPrefix = "Register"
+	handleMethodName   = "Handle"
+)
+
+var _ eapi.Plugin = &Plugin{}
+
+// Plugin 用于解析 grpc-gateway 生成的 HTTP 接口. e.g.
+//
+//	pb.RegisterBookServiceHandlerServer(ctx, mux, server)
+//	pb.RegisterBookServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+type Plugin struct {
+	config common.Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Name() string {
+	return "grpcgateway"
+}
+
+func (p *Plugin) Mount(k *koanf.Koanf) error {
+	return k.Unmarshal("properties", &p.config)
+}
+
+func (p *Plugin) Analyze(ctx *eapi.Context, node ast.Node) {
+	switch node := node.(type) {
+	case *ast.CallExpr:
+		p.callExpr(ctx, node)
+	}
+}
+
+func (p *Plugin) callExpr(ctx *eapi.Context, callExpr *ast.CallExpr) {
+	def := ctx.GetFuncDefinition(callExpr)
+	if def == nil || !p.isRegisterFunc(def) {
+		return
+	}
+	// 生成代码内部的相互调用 (e.g. RegisterXHandlerFromEndpoint 调用 RegisterXHandler) 不是路由声明
+	if def.Pkg().PkgPath == ctx.Package().PkgPath {
+		return
+	}
+
+	comment := ctx.ParseComment(ctx.GetHeadingCommentOf(callExpr.Pos()))
+	if comment.Ignore() {
+		return
+	}
+	apis := p.parseRegisterFunc(ctx, def, comment, make(map[*ast.FuncDecl]struct{}))
+	ctx.AddAPI(apis...)
+}
+
+// isRegisterFunc 判断是否是 grpc-gateway 生成的 Register*Handler* 函数
+func (p *Plugin) isRegisterFunc(def *eapi.FuncDefinition) bool {
+	if def.Decl.Recv != nil || !strings.HasPrefix(def.Decl.Name.Name, registerFuncPrefix) {
+		return false
+	}
+	fn, ok := def.Pkg().TypesInfo.Defs[def.Decl.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	params := fn.Type().(*types.Signature).Params()
+	for i := 0; i < params.Len(); i++ {
+		if params.At(i).Type().String() == serveMuxTypeName {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRegisterFunc 解析 Register*Handler* 函数中的 mux.Handle() 调用.
+// RegisterXHandlerFromEndpoint/RegisterXHandler 会调用 RegisterXHandlerClient, 所以需要继续解析同一个包中的 Register 函数
+func (p *Plugin) parseRegisterFunc(ctx *eapi.Context, def *eapi.FuncDefinition, comment *eapi.Comment, visited map[*ast.FuncDecl]struct{}) (apis []*eapi.API) {
+	if _, ok := visited[def.Decl]; ok || def.Decl.Body == nil {
+		return
+	}
+	visited[def.Decl] = struct{}{}
+
+	genCtx := ctx.NewEnv().WithPackage(def.Pkg()).WithFile(def.File())
+	ast.Inspect(def.Decl.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		genCtx.MatchCall(call, eapi.NewCallRule().WithRule(serveMuxTypeName, handleMethodName), func(call *ast.CallExpr, typeName, fnName string) {
+			api := p.parseAPI(ctx, genCtx, call, comment)
+			if api != nil {
+				apis = append(apis, api)
+			}
+		})
+
+		next := genCtx.GetFuncDefinition(call)
+		if next != nil && next.Pkg() == def.Pkg() && p.isRegisterFunc(next) {
+			apis = append(apis, p.parseRegisterFunc(ctx, next, comment, visited)...)
+		}
+		return true
+	})
+	return
+}
+
+// parseAPI 解析 mux.Handle("GET", pattern_Service_Method_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {...})
+func (p *Plugin) parseAPI(ctx *eapi.Context, genCtx *eapi.Context, call *ast.CallExpr, comment *eapi.Comment) (api *eapi.API) {
+	if len(call.Args) != 3 {
+		return
+	}
+	tv, ok := genCtx.Package().TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	method := strings.ToUpper(constant.StringVal(tv.Value))
+
+	pattern := p.parsePatternVar(genCtx, call.Args[1])
+	if pattern == nil {
+		return
+	}
+
+	handler, ok := call.Args[2].(*ast.FuncLit)
+	if !ok {
+		return
+	}
+	reqFn := p.getRequestFunc(genCtx, handler)
+	if reqFn == nil {
+		return
+	}
+	a := newHandlerAnalyzer(genCtx.NewEnv().WithPackage(reqFn.Pkg()).WithFile(reqFn.File()), reqFn.Decl)
+	a.Parse()
+	if a.rpc == nil {
+		return
+	}
+
+	api = eapi.NewAPI(method, pattern.path)
+	api.Spec.LoadFromComment(ctx, comment)
+	p.loadFromRPC(genCtx, api, a.rpc)
+	serviceName := p.serviceName(a.rpc)
+	if len(api.Spec.Tags) == 0 {
+		api.Spec.Tags = []string{serviceName}
+	}
+	if api.Spec.OperationID == "" {
+		api.Spec.OperationID = serviceName + "_" + a.rpc.Name()
+	}
+
+	sig := a.rpc.Type().(*types.Signature)
+	reqType := sig.Params().At(1).Type()
+	for _, name := range pattern.params {
+		param := spec.NewPathParameter(name).WithSchema(p.pathParamSchema(genCtx, reqType, name))
+		api.Spec.AddParameter(param)
+	}
+
+	if a.body != nil {
+		schema := a.ctx.GetSchemaByExpr(a.body, eapi.MimeTypeJson)
+		if schema != nil {
+			api.Spec.RequestBody = spec.NewRequestBody().WithSchemaRef(schema, []string{eapi.MimeTypeJson})
+		}
+	}
+	if a.query != nil {
+		params := eapi.NewParamParser(a.ctx, p.queryParamNameParser(pattern.params, a.bodyField)).Parse(a.query)
+		for _, param := range params {
+			// 忽略 path/body 中的字段以及无法作为 query 参数的 message 字段
+			if param.In != "query" || param.Schema == nil || param.Schema.Type == "" {
+				continue
+			}
+			api.Spec.AddParameter(param)
+		}
+	}
+
+	res := spec.NewResponse()
+	schema := genCtx.GetSchemaByType(sig.Results().At(0).Type(), eapi.MimeTypeJson)
+	res.Content = spec.NewContentWithSchemaRef(schema, []string{eapi.MimeTypeJson})
+	api.Spec.AddResponse(200, res)
+	return
+}
+
+// getRequestFunc 获取 handler 中调用的 request_Service_Method_0/local_request_Service_Method_0 函数
+func (p *Plugin) getRequestFunc(ctx *eapi.Context, handler *ast.FuncLit) (res *eapi.FuncDefinition) {
+	ast.Inspect(handler.Body, func(node ast.Node) bool {
+		if res != nil {
+			return false
+		}
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		def := ctx.GetFuncDefinition(call)
+		if def == nil || def.Pkg() != ctx.Package() {
+			return true
+		}
+		fn, ok := def.Pkg().TypesInfo.Defs[def.Decl.Name].(*types.Func)
+		if !ok {
+			return true
+		}
+		params := fn.Type().(*types.Signature).Params()
+		for i := 0; i < params.Len(); i++ {
+			if params.At(i).Type().String() == marshalerTypeName {
+				res = def
+				return false
+			}
+		}
+		return true
+	})
+	return
+}
+
+// loadFromRPC 使用 service interface 中 rpc 方法的注释 (即 proto 文件中的注释)
+func (p *Plugin) loadFromRPC(ctx *eapi.Context, api *eapi.API, rpc *types.Func) {
+	named, ok := rpc.Type().(*types.Signature).Recv().Type().(*types.Named)
+	if !ok {
+		return
+	}
+	def, ok := ctx.GetDefinition(named.Obj().Pkg().Path(), named.Obj().Name()).(*eapi.TypeDefinition)
+	if !ok {
+		return
+	}
+	iface, ok := def.Spec.Type.(*ast.InterfaceType)
+	if !ok {
+		return
+	}
+	for _, method := range iface.Methods.List {
+		if len(method.Names) == 0 || method.Names[0].Name != rpc.Name() {
+			continue
+		}
+		comment := ctx.WithPackage(def.Pkg()).WithFile(def.File()).ParseComment(method.Doc)
+		api.Spec.LoadFromComment(ctx, comment)
+		if text := strings.TrimSpace(strings.TrimPrefix(comment.Text(), rpc.Name())); text != "" {
+			api.Spec.Description = text
+		}
+		return
+	}
+}
+
+// serviceName 根据 service interface 的名字获取 service 名字. e.g. BookServiceServer/BookServiceClient => BookService
+func (p *Plugin) serviceName(rpc *types.Func) string {
+	named, ok := rpc.Type().(*types.Signature).Recv().Type().(*types.Named)
+	if !ok {
+		return ""
+	}
+	name := named.Obj().Name()
+	name = strings.TrimSuffix(name, "Server")
+	name = strings.TrimSuffix(name, "Client")
+	return name
+}
+
+// pathParamSchema 根据 path 参数对应的字段类型获取 schema. 参数名是 proto 字段名, 可以是嵌套字段 e.g. "book.book_id"
+func (p *Plugin) pathParamSchema(ctx *eapi.Context, reqType types.Type, name string) *spec.Schema {
+	t := reqType
+	for _, fieldName := range strings.Split(name, ".") {
+		t = p.protoFieldType(t, fieldName)
+		if t == nil {
+			return spec.NewStringSchema()
+		}
+	}
+	schema := ctx.GetSchemaByType(t, eapi.MimeTypeJson)
+	if schema == nil || schema.Ref != "" {
+		return spec.NewStringSchema()
+	}
+	return schema
+}
+
+func (p *Plugin) protoFieldType(t types.Type, protoName string) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+	for i := 0; i < st.NumFields(); i++ {
+		value, ok := tag.Parse(st.Tag(i))["protobuf"]
+		if !ok {
+			continue
+		}
+		if name, _ := tag.ParseProtobuf(value); name == protoName {
+			return st.Field(i).Type()
+		}
+	}
+	return nil
+}
+
+// queryParamNameParser 使用 protojson 中的字段名作为 query 参数名. path 参数和 body 对应的字段不是 query 参数
+func (p *Plugin) queryParamNameParser(pathParams []string, bodyField string) eapi.ParamNameParser {
+	pathFields := lo.Map(pathParams, func(name string, _ int) string {
+		name, _, _ = strings.Cut(name, ".")
+		return name
+	})
+	return func(field string, tags map[string]string) (name, in string) {
+		value, ok := tags["protobuf"]
+		if !ok || field == bodyField {
+			return field, ""
+		}
+		protoName, jsonName := tag.ParseProtobuf(value)
+		if lo.Contains(pathFields, protoName) {
+			return field, ""
+		}
+		return jsonName, "query"
+	}
+}
@@ -0,0 +1,110 @@
+package grpcgateway
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/gotomicro/eapi"
+)
+
+const (
+	decoderTypeName             = runtimePackageName + ".Decoder"
+	populateQueryParametersFunc = "PopulateQueryParameters"
+)
+
+// handlerAnalyzer 用于解析生成代码中的 request_Service_Method_0/local_request_Service_Method_0 函数
+type handlerAnalyzer struct {
+	ctx  *eapi.Context
+	decl *ast.FuncDecl
+
+	// 调用的 rpc 方法. e.g. server.GetBook(ctx, &protoReq)
+	rpc *types.Func
+	// request body 对应的表达式. e.g. &protoReq / &protoReq.Book
+	body ast.Expr
+	// request body 对应的字段名. body 为整个 message 时为空
+	bodyField string
+	// 从 query 中解析参数的 message. e.g. runtime.PopulateQueryParameters(&protoReq, req.Form, filter)
+	query ast.Expr
+}
+
+func newHandlerAnalyzer(ctx *eapi.Context, decl *ast.FuncDecl) *handlerAnalyzer {
+	return &handlerAnalyzer{ctx: ctx, decl: decl}
+}
+
+func (p *handlerAnalyzer) Parse() {
+	ast.Inspect(p.decl, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		p.ctx.MatchCall(call,
+			eapi.NewCallRule().
+				WithRule(decoderTypeName, "Decode").
+				WithRule(runtimePackageName, populateQueryParametersFunc),
+			func(call *ast.CallExpr, typeName, fnName string) {
+				switch fnName {
+				case "Decode":
+					p.parseDecode(call)
+				case populateQueryParametersFunc:
+					if len(call.Args) > 0 {
+						p.query = call.Args[0]
+					}
+				}
+			},
+		)
+		p.parseRPCCall(call)
+		return true
+	})
+}
+
+// parseDecode 解析 marshaler.NewDecoder(newReader()).Decode(&protoReq.Book)
+func (p *handlerAnalyzer) parseDecode(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	p.body = call.Args[0]
+	arg := p.body
+	if unary, ok := arg.(*ast.UnaryExpr); ok {
+		arg = unary.X
+	}
+	if sel, ok := arg.(*ast.SelectorExpr); ok {
+		p.bodyField = sel.Sel.Name
+	}
+}
+
+// parseRPCCall 解析对 service interface 方法的调用. e.g. server.GetBook(ctx, &protoReq) / client.GetBook(ctx, &protoReq, ...)
+func (p *handlerAnalyzer) parseRPCCall(call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	recv, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	recvType := p.ctx.Package().TypesInfo.TypeOf(recv)
+	if recvType == nil || !types.IsInterface(recvType) || !p.isParam(recv) {
+		return
+	}
+	fn := p.ctx.GetFuncFromAstNode(sel)
+	if fn == nil {
+		return
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() < 2 || sig.Results().Len() != 2 {
+		return
+	}
+	p.rpc = fn
+}
+
+func (p *handlerAnalyzer) isParam(ident *ast.Ident) bool {
+	obj := p.ctx.Package().TypesInfo.ObjectOf(ident)
+	for _, field := range p.decl.Type.Params.List {
+		for _, name := range field.Names {
+			if p.ctx.Package().TypesInfo.Defs[name] == obj {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package grpcgateway
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+)
+
+// OpCode 参考 github.com/grpc-ecosystem/grpc-gateway/v2/utilities.OpCode
+const (
+	opNop     = iota // no operation
+	opPush           // 匹配一个任意的路径片段 (*)
+	opLitPush        // 匹配一个字面量路径片段
+	opPushM          // 匹配剩余的所有路径片段 (**)
+	opConcatN        // 将栈顶的 N 个片段合并为一个
+	opCapture        // 将栈顶的片段绑定到变量
+)
+
+const newPatternFuncName = "NewPattern"
+
+type pattern struct {
+	path string
+	// path 参数名 (proto 字段名). e.g. "book_id", "book.book_id"
+	params []string
+}
+
+// parsePatternVar 解析生成代码中的 pattern 变量. e.g.
+//
+//	pattern_BookService_GetBook_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v1", "books", "book_id"}, ""))
+func (p *Plugin) parsePatternVar(ctx *eapi.Context, expr ast.Expr) *pattern {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	obj, ok := ctx.Package().TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok {
+		return nil
+	}
+	value := ctx.LookupVarValue(obj)
+	if value == nil {
+		return nil
+	}
+
+	var res *pattern
+	ast.Inspect(value, func(node ast.Node) bool {
+		if res != nil {
+			return false
+		}
+		ctx.MatchCall(node, eapi.NewCallRule().WithRule(runtimePackageName, newPatternFuncName), func(call *ast.CallExpr, typeName, fnName string) {
+			res = p.parseNewPattern(ctx, call)
+		})
+		return true
+	})
+	return res
+}
+
+// parseNewPattern 解析 runtime.NewPattern(version, ops, pool, verb)
+func (p *Plugin) parseNewPattern(ctx *eapi.Context, call *ast.CallExpr) *pattern {
+	if len(call.Args) != 4 {
+		return nil
+	}
+	opsLit, ok := call.Args[1].(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	poolLit, ok := call.Args[2].(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	var ops []int
+	for _, elt := range opsLit.Elts {
+		tv, ok := ctx.Package().TypesInfo.Types[elt]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+			return nil
+		}
+		v, _ := constant.Int64Val(tv.Value)
+		ops = append(ops, int(v))
+	}
+	var pool []string
+	for _, elt := range poolLit.Elts {
+		tv, ok := ctx.Package().TypesInfo.Types[elt]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return nil
+		}
+		pool = append(pool, constant.StringVal(tv.Value))
+	}
+	var verb string
+	if tv, ok := ctx.Package().TypesInfo.Types[call.Args[3]]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		verb = constant.StringVal(tv.Value)
+	}
+
+	return decodePattern(ops, pool, verb)
+}
+
+// decodePattern 将 grpc-gateway 编译后的 pattern 还原为 OpenAPI 格式的路径
+func decodePattern(ops []int, pool []string, verb string) *pattern {
+	res := &pattern{}
+	var stack []string
+	for i := 0; i+1 < len(ops); i += 2 {
+		code, operand := ops[i], ops[i+1]
+		switch code {
+		case opNop:
+		case opPush:
+			stack = append(stack, "*")
+		case opPushM:
+			stack = append(stack, "**")
+		case opLitPush:
+			if operand < 0 || operand >= len(pool) {
+				return nil
+			}
+			stack = append(stack, pool[operand])
+		case opConcatN:
+			if operand <= 0 || operand > len(stack) {
+				return nil
+			}
+			n := len(stack) - operand
+			stack = append(stack[:n], strings.Join(stack[n:], "/"))
+		case opCapture:
+			if operand < 0 || operand >= len(pool) || len(stack) == 0 {
+				return nil
+			}
+			res.params = append(res.params, pool[operand])
+			stack[len(stack)-1] = "{" + pool[operand] + "}"
+		default:
+			return nil
+		}
+	}
+
+	res.path = "/" + strings.Join(stack, "/")
+	if verb != "" {
+		res.path += ":" + verb
+	}
+	return res
+}
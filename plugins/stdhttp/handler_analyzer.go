@@ -0,0 +1,322 @@
+package stdhttp
+
+import (
+	"go/ast"
+	"go/types"
+	"net/http"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/spec"
+	"github.com/iancoleman/strcase"
+)
+
+const (
+	requestTypeName        = "*net/http.Request"
+	responseWriterTypeName = "net/http.ResponseWriter"
+	urlTypeName            = "*net/url.URL"
+	urlValuesTypeName      = "net/url.Values"
+	jsonDecoderTypeName    = "*encoding/json.Decoder"
+	jsonEncoderTypeName    = "*encoding/json.Encoder"
+)
+
+var (
+	interestedRequestMethods = []string{"PathValue", "FormValue", "PostFormValue", "FormFile", "Cookie"}
+)
+
+// HandlerAnalyzer 用于解析 func(w http.ResponseWriter, r *http.Request) 形式的 handler 函数
+type HandlerAnalyzer struct {
+	ctx  *eapi.Context
+	api  *eapi.API
+	spec *eapi.APISpec
+	decl *ast.FuncDecl
+
+	c *common.Config
+
+	// status code set by the latest w.WriteHeader()
+	status int
+	// 通过 http.SetCookie 设置的 cookie
+	setCookies []string
+}
+
+func NewHandlerAnalyzer(ctx *eapi.Context, api *eapi.API, decl *ast.FuncDecl) *HandlerAnalyzer {
+	return &HandlerAnalyzer{ctx: ctx, api: api, spec: api.Spec, decl: decl}
+}
+
+func (p *HandlerAnalyzer) WithConfig(c *common.Config) *HandlerAnalyzer {
+	p.c = c
+	return p
+}
+
+func (p *HandlerAnalyzer) Parse() {
+	ast.Inspect(p.decl, func(node ast.Node) bool {
+		customRuleAnalyzer := common.NewCustomRuleAnalyzer(
+			p.ctx,
+			p.spec,
+			p.api,
+			p.c,
+		)
+		matched := customRuleAnalyzer.MatchCustomResponseRule(node)
+		if matched {
+			return true
+		}
+		matched = customRuleAnalyzer.MatchCustomRequestRule(node)
+		if matched {
+			return true
+		}
+
+		p.ctx.MatchCall(node,
+			eapi.NewCallRule().
+				WithRule(requestTypeName, interestedRequestMethods...).
+				WithRule(responseWriterTypeName, "WriteHeader").
+				WithRule(urlValuesTypeName, "Get").
+				WithRule(jsonDecoderTypeName, "Decode").
+				WithRule(jsonEncoderTypeName, "Encode").
+				WithRule(httpPackageName, "Error", "SetCookie"),
+			func(call *ast.CallExpr, typeName, fnName string) {
+				switch typeName + "." + fnName {
+				case requestTypeName + ".PathValue":
+					p.parsePrimitiveParam(call, "path")
+				case requestTypeName + ".FormValue":
+					p.parseFormValue(call)
+				case requestTypeName + ".PostFormValue":
+					p.parseFormData(call, "string")
+				case requestTypeName + ".FormFile":
+					p.parseFormData(call, "file")
+				case requestTypeName + ".Cookie":
+					p.parsePrimitiveParam(call, "cookie")
+				case urlValuesTypeName + ".Get":
+					p.parseQueryValue(call)
+				case jsonDecoderTypeName + ".Decode":
+					p.parseRequestBody(call, eapi.MimeTypeJson)
+				case jsonEncoderTypeName + ".Encode":
+					p.parseResBody(call, eapi.MimeTypeJson)
+				case responseWriterTypeName + ".WriteHeader":
+					p.parseWriteHeader(call)
+				case httpPackageName + ".Error":
+					p.parseErrorRes(call)
+				case httpPackageName + ".SetCookie":
+					p.parseSetCookie(call)
+				}
+			},
+		)
+		return true
+	})
+	common.AddSetCookieHeader(p.spec, p.setCookies)
+}
+
+// parseSetCookie 解析 http.SetCookie(w, &http.Cookie{Name: "session"})
+func (p *HandlerAnalyzer) parseSetCookie(call *ast.CallExpr) {
+	if len(call.Args) != 2 {
+		return
+	}
+	if name, ok := common.CookieName(p.ctx, call.Args[1]); ok {
+		p.setCookies = append(p.setCookies, name)
+	}
+}
+
+// parseQueryValue 解析 r.URL.Query().Get("name") 或者 q := r.URL.Query(); q.Get("name")
+// r.Form/r.PostForm 以及 handler 中自行构造的 url.Values 不是 query 参数
+func (p *HandlerAnalyzer) parseQueryValue(call *ast.CallExpr) {
+	selExpr, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	x := selExpr.X
+	if ident, ok := x.(*ast.Ident); ok {
+		v, ok := p.ctx.Package().TypesInfo.ObjectOf(ident).(*types.Var)
+		if !ok {
+			return
+		}
+		x = p.ctx.LookupVarValue(v)
+	}
+	queryCall, ok := x.(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	typeName, fnName, err := p.ctx.GetCallInfo(queryCall)
+	if err != nil || typeName != urlTypeName || fnName != "Query" {
+		return
+	}
+	p.parsePrimitiveParam(call, "query")
+}
+
+// parseFormValue r.FormValue() 会同时读取 query 参数和 body 中的表单参数
+func (p *HandlerAnalyzer) parseFormValue(call *ast.CallExpr) {
+	switch p.api.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		p.parsePrimitiveParam(call, "query")
+	default:
+		p.parseFormData(call, "string")
+	}
+}
+
+// parseRequestBody 解析 json.NewDecoder(r.Body).Decode(&req)
+func (p *HandlerAnalyzer) parseRequestBody(call *ast.CallExpr, contentType string) {
+	if len(call.Args) != 1 {
+		return
+	}
+	arg0 := call.Args[0]
+
+	schema := p.ctx.GetSchemaByExpr(arg0, contentType)
+	if schema == nil {
+		return
+	}
+	commentGroup := p.ctx.GetHeadingCommentOf(call.Pos())
+	if commentGroup != nil {
+		comment := p.ctx.ParseComment(commentGroup)
+		schema.Description = comment.Text()
+	}
+	reqBody := spec.NewRequestBody().WithSchemaRef(schema, []string{contentType})
+	p.spec.RequestBody = reqBody
+}
+
+// parseResBody 解析 json.NewEncoder(w).Encode(res). 状态码取自前面最近一次调用的 w.WriteHeader()
+func (p *HandlerAnalyzer) parseResBody(call *ast.CallExpr, contentType string) {
+	if len(call.Args) != 1 {
+		return
+	}
+
+	res := spec.NewResponse()
+	commentGroup := p.ctx.GetHeadingCommentOf(call.Pos())
+	if commentGroup != nil {
+		comment := p.ctx.ParseComment(commentGroup)
+		res.Description = comment.TextPointer()
+	}
+
+	schema := p.ctx.GetSchemaByExpr(call.Args[0], contentType)
+	res.Content = spec.NewContentWithSchemaRef(schema, []string{contentType})
+	statusCode := p.status
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	p.status = 0
+	if existing := p.spec.Responses.Get(statusCode); existing != nil && res.Description == nil {
+		res.Description = existing.Description
+	}
+	p.spec.AddResponse(statusCode, res)
+}
+
+func (p *HandlerAnalyzer) parseWriteHeader(call *ast.CallExpr) {
+	if len(call.Args) != 1 {
+		return
+	}
+	p.status = p.ctx.ParseStatusCode(call.Args[0])
+	if p.spec.Responses.Get(p.status) != nil {
+		return
+	}
+
+	// 没有响应 body 的情况. 例如 w.WriteHeader(http.StatusNoContent)
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	p.spec.AddResponse(p.status, res)
+}
+
+// parseErrorRes 解析 http.Error(w, "message", code)
+func (p *HandlerAnalyzer) parseErrorRes(call *ast.CallExpr) {
+	if len(call.Args) != 3 {
+		return
+	}
+
+	res := spec.NewResponse()
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	res.Description = comment.TextPointer()
+	res.Content = spec.NewContentWithSchema(spec.NewStringSchema(), []string{"text/plain"})
+	statusCode := p.ctx.ParseStatusCode(call.Args[2])
+	p.spec.AddResponse(statusCode, res)
+}
+
+func (p *HandlerAnalyzer) parsePrimitiveParam(call *ast.CallExpr, in string) {
+	param := p.primitiveParam(call, in)
+	if param == nil {
+		return
+	}
+	p.spec.AddParameter(param)
+}
+
+func (p *HandlerAnalyzer) parseFormData(call *ast.CallExpr, fieldType string, options ...func(s *spec.Schema)) {
+	if len(call.Args) <= 0 {
+		return
+	}
+	arg0 := call.Args[0]
+	arg0Lit, ok := arg0.(*ast.BasicLit)
+	if !ok {
+		return
+	}
+
+	name := strings.Trim(arg0Lit.Value, "\"")
+	paramSchema := spec.NewSchema()
+	paramSchema.Title = name
+	paramSchema.Type = fieldType
+	for _, option := range options {
+		option(paramSchema)
+	}
+
+	requestBody := p.spec.RequestBody
+	if requestBody == nil {
+		requestBody = spec.NewRequestBody().WithContent(spec.NewContent())
+		p.spec.RequestBody = requestBody
+	}
+	mediaType := requestBody.GetMediaType(eapi.MimeTypeFormData)
+	if mediaType == nil {
+		mediaType = spec.NewMediaType()
+		requestBody.Content[eapi.MimeTypeFormData] = mediaType
+	}
+
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+	paramSchema.Description = comment.Text()
+
+	var schemaRef = mediaType.Schema
+	var schema *spec.SchemaRef
+	if schemaRef != nil {
+		schema = spec.Unref(p.ctx.Doc(), schemaRef)
+		schema.WithProperty(name, paramSchema)
+	} else {
+		schema = spec.NewObjectSchema().NewRef()
+		title := strcase.ToCamel(p.spec.OperationID) + "Request"
+		schema.Title = title
+		schema.WithProperty(name, paramSchema)
+		p.ctx.Doc().Components.Schemas[title] = schema
+		schemaRef = spec.RefComponentSchemas(title)
+		mediaType.Schema = schemaRef
+	}
+	if comment.Required() {
+		schema.Required = append(schema.Required, name)
+	}
+}
+
+func (p *HandlerAnalyzer) primitiveParam(call *ast.CallExpr, in string) *spec.Parameter {
+	if len(call.Args) <= 0 {
+		return nil
+	}
+	arg0 := call.Args[0]
+	arg0Lit, ok := arg0.(*ast.BasicLit)
+	if !ok {
+		return nil
+	}
+	name := strings.Trim(arg0Lit.Value, "\"")
+	paramSchema := spec.NewSchema()
+	paramSchema.Title = name
+	paramSchema.Type = "string"
+
+	comment := p.ctx.ParseComment(p.ctx.GetHeadingCommentOf(call.Pos()))
+
+	var res *spec.Parameter
+	switch in {
+	case "path":
+		res = spec.NewPathParameter(name).WithSchema(paramSchema)
+	case "query":
+		res = spec.NewQueryParameter(name).WithSchema(paramSchema)
+		res.Required = comment.Required()
+	case "cookie":
+		res = spec.NewCookieParameter(name).WithSchema(paramSchema)
+		res.Required = comment.Required()
+	default:
+		return nil
+	}
+
+	res.Description = comment.Text()
+	return res
+}
@@ -0,0 +1,204 @@
+package stdhttp
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/common"
+	"github.com/gotomicro/eapi/utils"
+	"github.com/knadh/koanf"
+)
+
+const (
+	serveMuxTypeName   = "*net/http.ServeMux"
+	httpPackageName    = "net/http"
+	serveHTTPMethod    = "ServeHTTP"
+	handleMethodName   = "Handle"
+	handleFuncName     = "HandleFunc"
+	defaultRouteMethod = http.MethodGet
+)
+
+var routeMethods = []string{handleMethodName, handleFuncName}
+
+var _ eapi.Plugin = &Plugin{}
+
+// Plugin 用于解析标准库 net/http 中 http.ServeMux 的路由声明 (支持 Go 1.22 的 "METHOD /path/{param}" 格式)
+type Plugin struct {
+	config common.Config
+}
+
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+func (p *Plugin) Name() string {
+	return "stdhttp"
+}
+
+func (p *Plugin) Mount(k *koanf.Koanf) error {
+	return k.Unmarshal("properties", &p.config)
+}
+
+func (p *Plugin) Analyze(ctx *eapi.Context, node ast.Node) {
+	switch node := node.(type) {
+	case *ast.CallExpr:
+		p.callExpr(ctx, node)
+	}
+}
+
+func (p *Plugin) callExpr(ctx *eapi.Context, callExpr *ast.CallExpr) {
+	callRule := eapi.NewCallRule().
+		WithRule(serveMuxTypeName, routeMethods...).
+		WithRule(httpPackageName, routeMethods...)
+	for _, router := range p.config.RouterNames {
+		callRule = callRule.WithRule(router, routeMethods...)
+	}
+
+	ctx.MatchCall(
+		callExpr,
+		callRule,
+		func(call *ast.CallExpr, typeName, fnName string) {
+			comment := ctx.ParseComment(ctx.GetHeadingCommentOf(call.Pos()))
+			if comment.Ignore() {
+				return
+			}
+			api := p.parseAPI(ctx, call, comment)
+			if api == nil {
+				return
+			}
+			ctx.AddAPI(api)
+		},
+	)
+}
+
+func (p *Plugin) parseAPI(ctx *eapi.Context, callExpr *ast.CallExpr, comment *eapi.Comment) (api *eapi.API) {
+	if len(callExpr.Args) < 2 {
+		return
+	}
+	pattern, ok := ctx.EvalString(callExpr.Args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unable to resolve route pattern at %s\n", ctx.LineColumn(callExpr.Args[0].Pos()))
+		return
+	}
+
+	handlerFn := ResolveHandlerFunc(ctx, callExpr.Args[len(callExpr.Args)-1])
+	if handlerFn == nil {
+		return
+	}
+	typeName, methodName := utils.GetFuncInfo(handlerFn)
+	handlerDef := ctx.GetDefinition(typeName, methodName)
+	if handlerDef == nil {
+		fmt.Fprintf(os.Stderr, "handler function %s.%s not found\n", typeName, methodName)
+		return
+	}
+	handlerFnDef, ok := handlerDef.(*eapi.FuncDefinition)
+	if !ok {
+		return
+	}
+
+	method, fullPath := p.parsePattern(pattern)
+	api = eapi.NewAPI(method, fullPath)
+	api.Spec.LoadFromComment(ctx, comment)
+	api.Spec.LoadFromFuncDecl(ctx, handlerFnDef.Decl)
+	if api.Spec.OperationID == "" {
+		id := comment.ID()
+		if id == "" {
+			id = HandlerOperationID(handlerFnDef)
+		}
+		api.Spec.OperationID = id
+	}
+	NewHandlerAnalyzer(
+		ctx.NewEnv().WithPackage(handlerFnDef.Pkg()).WithFile(handlerFnDef.File()),
+		api,
+		handlerFnDef.Decl,
+	).WithConfig(&p.config).Parse()
+
+	return
+}
+
+// HandlerOperationID 生成 handler 函数默认的 operationId. 所有 http.Handler 的方法名都是 ServeHTTP, 所以需要带上接收者类型名. e.g.
+//
+//	handler.List
+//	handler.FileHandler.ServeHTTP
+func HandlerOperationID(def *eapi.FuncDefinition) string {
+	id := def.Pkg().Name + "." + def.Decl.Name.Name
+	if def.Decl.Name.Name != serveHTTPMethod || def.Decl.Recv.NumFields() != 1 {
+		return id
+	}
+	recv := def.Decl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	switch t := recv.(type) {
+	case *ast.IndexExpr:
+		recv = t.X
+	case *ast.IndexListExpr:
+		recv = t.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		id = def.Pkg().Name + "." + ident.Name + "." + def.Decl.Name.Name
+	}
+	return id
+}
+
+// ResolveHandlerFunc 解析 http.Handler/http.HandlerFunc 对应的 handler 函数. 支持以下几种写法:
+//
+//	mux.HandleFunc("GET /x", h.X)
+//	mux.Handle("GET /x", http.HandlerFunc(h.X))
+//	mux.Handle("GET /x", &xHandler{}) // 实现了 ServeHTTP 方法的类型
+func ResolveHandlerFunc(ctx *eapi.Context, handlerArg ast.Expr) (handlerFn *types.Func) {
+	if call, ok := handlerArg.(*ast.CallExpr); ok {
+		nestedCall := utils.UnwrapCall(call)
+		if len(nestedCall.Args) > 0 {
+			handlerArg = nestedCall.Args[0]
+		}
+	}
+	handlerFn = ctx.GetFuncFromAstNode(handlerArg)
+	if handlerFn != nil {
+		return
+	}
+
+	t := ctx.Package().TypesInfo.TypeOf(handlerArg)
+	if t == nil {
+		return nil
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, serveHTTPMethod)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil
+	}
+	return fn
+}
+
+var (
+	wildcardPattern = regexp.MustCompile(`\{([^{}.$]+)(\.\.\.)?\}`)
+)
+
+// parsePattern 解析 http.ServeMux 路由格式 "[METHOD ][HOST]/[PATH]"
+// 未指定 METHOD 的路由会匹配所有请求方法，此时文档中使用 GET 方法
+func (p *Plugin) parsePattern(pattern string) (method string, path string) {
+	method = defaultRouteMethod
+	pattern = strings.TrimSpace(pattern)
+	if m, rest, found := strings.Cut(pattern, " "); found {
+		method = strings.ToUpper(m)
+		pattern = strings.TrimSpace(rest)
+	}
+
+	// strip host
+	if idx := strings.Index(pattern, "/"); idx > 0 {
+		pattern = pattern[idx:]
+	}
+
+	path = strings.ReplaceAll(pattern, "{$}", "")
+	path = wildcardPattern.ReplaceAllString(path, "{$1}")
+	if path == "" {
+		path = "/"
+	}
+	return
+}
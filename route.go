@@ -1,16 +1,37 @@
 package eapi
 
 import (
+	"errors"
+	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
 	"net/http"
 	"strings"
 
 	"github.com/gotomicro/eapi/annotation"
 	"github.com/gotomicro/eapi/spec"
+	"github.com/samber/lo"
 )
 
 type RouteGroup struct {
 	Prefix string
+	// 作用于分组内路由的中间件, 包括从父分组继承的中间件
+	Middlewares []*FuncDefinition
+}
+
+// NewRouteGroup 创建路由分组. parent 不为空时继承 parent 的中间件
+func NewRouteGroup(parent *RouteGroup, prefix string) *RouteGroup {
+	rg := &RouteGroup{Prefix: prefix}
+	if parent != nil {
+		rg.Use(parent.Middlewares...)
+	}
+	return rg
+}
+
+// Use 添加中间件. 和 gin/echo 一致, 只作用于之后注册的路由和之后创建的子分组
+func (g *RouteGroup) Use(middlewares ...*FuncDefinition) {
+	g.Middlewares = lo.Uniq(append(append([]*FuncDefinition{}, g.Middlewares...), middlewares...))
 }
 
 type API struct {
@@ -61,6 +82,24 @@ func (r *APIs) add(items ...*API) {
 type APISpec struct {
 	Consumes []string
 	*spec.Operation
+	// 注释中通过 @param 声明的参数. 在路由解析完成之后与推导出的参数合并
+	commentParams []*spec.Parameter
+	// 注释中通过 @response 声明的响应
+	commentResponses []*commentResponse
+	// 注释中通过 @example request/response 声明的示例
+	commentExamples []*annotation.ExampleAnnotation
+	// 注释中通过 @header 声明的响应头
+	commentHeaders []*annotation.HeaderAnnotation
+	// 通过 @hidden-in-production 声明. 生成生产环境的文档时隐藏该接口
+	hiddenInProduction bool
+}
+
+// commentResponse @response 注解以及解析其中类型所用的上下文
+type commentResponse struct {
+	ctx   *Context
+	annot *annotation.ResponseAnnotation
+	// 注解所在的位置
+	pos token.Position
 }
 
 func NewAPISpec() *APISpec {
@@ -75,8 +114,12 @@ func NewAPISpec() *APISpec {
 
 // LoadFromFuncDecl load annotations/description from comments of handler function
 func (s *APISpec) LoadFromFuncDecl(ctx *Context, funcDecl *ast.FuncDecl) {
+	if def := ctx.analyzer.funcDefinitionOf(funcDecl); def != nil {
+		// handler 注释中的类型需要在 handler 所在的文件中解析
+		ctx = ctx.WithPackage(def.pkg).WithFile(def.file)
+	}
 	cg := funcDecl.Doc
-	comment := ParseComment(cg, ctx.Package().Fset)
+	comment := ctx.ParseComment(cg)
 	s.LoadFromComment(ctx, comment)
 	if s.Description == "" {
 		// 使用注释里的普通文本作为描述
@@ -107,6 +150,37 @@ func (s *APISpec) LoadFromComment(ctx *Context, comment *Comment) {
 		if s.Security == nil {
 			s.Security = comment.Security()
 		}
+		s.commentParams = append(s.commentParams, comment.Params()...)
+		s.commentExamples = append(s.commentExamples, comment.OperationExamples()...)
+		s.commentHeaders = append(s.commentHeaders, comment.Headers()...)
+		if s.ExternalDocs == nil {
+			s.ExternalDocs = comment.ExternalDocs()
+		}
+		if s.Servers == nil {
+			if servers := comment.Servers(); len(servers) > 0 {
+				s.Servers = &servers
+			}
+		}
+		for name, value := range comment.Extensions() {
+			if s.Extensions == nil {
+				s.Extensions = make(map[string]interface{})
+			}
+			if _, ok := s.Extensions[name]; !ok {
+				s.Extensions[name] = value
+			}
+		}
+		for _, annot := range comment.Callbacks() {
+			s.addCallback(ctx, annot, comment.Position(annot))
+		}
+		for _, annot := range comment.Responses() {
+			s.commentResponses = append(s.commentResponses, &commentResponse{ctx: ctx, annot: annot, pos: comment.Position(annot)})
+		}
+		if comment.HiddenInProduction() {
+			s.hiddenInProduction = true
+		}
+	}
+	if len(ctx.CommentStack().LookupAnnotations(annotation.HiddenInProduction)) > 0 {
+		s.hiddenInProduction = true
 	}
 	if len(s.Tags) == 0 {
 		s.Tags = ctx.CommentStack().LookupTags()
@@ -115,3 +189,133 @@ func (s *APISpec) LoadFromComment(ctx *Context, comment *Comment) {
 		s.Security = convertSecAnnotationToSecurityRequirements(ctx.CommentStack().LookupAnnotations(annotation.Security))
 	}
 }
+
+// mergeCommentParams 合并 @param 声明的参数. 同名的推导参数只覆盖描述, 声明了 required 时标记为必填; 否则添加为新的参数
+func (s *APISpec) mergeCommentParams() {
+	for _, param := range s.commentParams {
+		exists := s.Parameters.GetByInAndName(param.In, param.Name)
+		if exists == nil {
+			s.AddParameter(param)
+			continue
+		}
+		if param.Description != "" {
+			exists.Description = param.Description
+		}
+		if param.Required {
+			exists.Required = true
+		}
+	}
+	s.commentParams = nil
+}
+
+// mergeCommentResponses 合并 @response 声明的响应. 已经推导出同一状态码的响应时, 使用声明的类型替换响应内容的 schema
+func (s *APISpec) mergeCommentResponses() {
+	for _, item := range s.commentResponses {
+		code := item.annot.Status
+		res := s.Responses[code]
+		if res == nil {
+			res = spec.NewResponse()
+			s.Responses[code] = res
+		}
+		if item.annot.Description != "" {
+			desc := item.annot.Description
+			res.Description = &desc
+		}
+		if item.annot.DataType == "" {
+			continue
+		}
+		schema, err := commentTypeSchema(item.ctx, item.annot.DataType)
+		if err != nil {
+			item.ctx.analyzer.report(&Diagnostic{
+				Position: item.pos,
+				Message:  fmt.Sprintf("invalid type '%s' in @response: %s", item.annot.DataType, err.Error()),
+			})
+			continue
+		}
+		if len(res.Content) == 0 {
+			res.Content = spec.NewContentWithSchemaRef(schema, []string{"application/json"})
+			continue
+		}
+		for _, mediaType := range res.Content {
+			mediaType.Schema = schema
+		}
+	}
+	s.commentResponses = nil
+}
+
+// commentTypeSchema 在注释所在的文件中解析 @response/@callback 等注解中声明的类型
+func commentTypeSchema(ctx *Context, dataType string) (*spec.SchemaRef, error) {
+	pkg := ctx.Package()
+	pos := token.NoPos
+	if file := ctx.File(); file != nil {
+		pos = file.Pos()
+	}
+	tv, err := types.Eval(pkg.Fset, pkg.Types, pos, dataType)
+	if e, ok := err.(types.Error); ok {
+		err = errors.New(e.Msg)
+	}
+	if err == nil && !tv.IsType() {
+		err = fmt.Errorf("%s is not a type", dataType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ctx.GetSchemaByType(tv.Type, "application/json"), nil
+}
+
+// mergeCommentHeaders 为响应添加 @header 声明的响应头
+func (s *APISpec) mergeCommentHeaders() {
+	for _, annot := range s.commentHeaders {
+		res := s.Responses[annot.Status]
+		if res == nil {
+			res = spec.NewResponse()
+			s.Responses[annot.Status] = res
+		}
+		if res.Headers == nil {
+			res.Headers = make(spec.Headers)
+		}
+		res.Headers[annot.Name] = &spec.HeaderRef{Value: &spec.Header{Parameter: spec.Parameter{
+			Description: annot.Description,
+			Schema:      annotationDataSchema(annot.DataType),
+		}}}
+	}
+	s.commentHeaders = nil
+}
+
+// addCallback 添加 @callback 声明的回调. 回调请求的请求体为声明的类型
+func (s *APISpec) addCallback(ctx *Context, annot *annotation.CallbackAnnotation, pos token.Position) {
+	schema, err := commentTypeSchema(ctx, annot.DataType)
+	if err != nil {
+		ctx.analyzer.report(&Diagnostic{
+			Position: pos,
+			Message:  fmt.Sprintf("invalid type '%s' in @callback: %s", annot.DataType, err.Error()),
+		})
+		return
+	}
+	op := spec.NewOperation()
+	op.RequestBody = spec.NewRequestBody().WithJSONSchemaRef(schema)
+	op.Responses = spec.Responses{"200": spec.NewResponse().WithDescription("OK")}
+
+	if s.Callbacks == nil {
+		s.Callbacks = make(spec.Callbacks)
+	}
+	ref := s.Callbacks[annot.Name]
+	if ref == nil {
+		ref = &spec.CallbackRef{Value: &spec.Callback{}}
+		s.Callbacks[annot.Name] = ref
+	}
+	pathItem := (*ref.Value)[annot.URL]
+	if pathItem == nil {
+		pathItem = &spec.PathItem{}
+		(*ref.Value)[annot.URL] = pathItem
+	}
+	pathItem.SetOperation(annot.Method, op)
+}
+
+// mergeComment 在路由解析完成之后合并注释中声明的参数, 响应和示例
+func (s *APISpec) mergeComment() {
+	s.mergeCommentParams()
+	s.mergeCommentResponses()
+	s.mergeCommentHeaders()
+	s.mergeCommentExamples()
+}
@@ -87,7 +87,18 @@ func (s *SchemaBuilder) parseTypeSpec(t *ast.TypeSpec) *spec.SchemaRef {
 		}
 	}
 
-	schema := s.setTypeParams(typeParams).ParseExpr(t.Type)
+	comment := s.ctx.ParseComment(s.ctx.GetHeadingCommentOf(t.Type.Pos()))
+	var schema *spec.SchemaRef
+	if override := comment.Schema(); override != nil {
+		schema = override
+	} else if _, ok := t.Type.(*ast.InterfaceType); ok {
+		schema = s.parseInterfaceType(t, comment)
+	} else if obj := s.ctx.Package().TypesInfo.Defs[t.Name]; obj != nil && t.TypeParams == nil {
+		schema = marshalerSchema(obj.Type())
+	}
+	if schema == nil {
+		schema = s.setTypeParams(typeParams).ParseExpr(t.Type)
+	}
 	if schema == nil {
 		return nil
 	}
@@ -95,7 +106,6 @@ func (s *SchemaBuilder) parseTypeSpec(t *ast.TypeSpec) *spec.SchemaRef {
 		schema.ExtendedTypeInfo.TypeParams = typeParams
 	}
 
-	comment := s.ctx.ParseComment(s.ctx.GetHeadingCommentOf(t.Type.Pos()))
 	comment.ApplyToSchema(schema)
 	if schema.Ref == "" {
 		schema.Title = strcase.ToCamel(s.ctx.Package().Name + t.Name.Name)
@@ -129,6 +139,11 @@ func (s *SchemaBuilder) ParseExpr(expr ast.Expr) (schema *spec.SchemaRef) {
 		return s.parseIdent(expr)
 
 	case *ast.SelectorExpr:
+		if types.IsInterface(s.ctx.Package().TypesInfo.TypeOf(expr)) { // resp.Data
+			if schema := s.parseConcreteValues(s.ctx.concreteValues(expr, make(map[interface{}]bool))); schema != nil {
+				return schema
+			}
+		}
 		return s.ParseExpr(expr.Sel)
 
 	case *ast.MapType:
@@ -150,7 +165,7 @@ func (s *SchemaBuilder) ParseExpr(expr ast.Expr) (schema *spec.SchemaRef) {
 		return s.ParseExpr(expr.X)
 
 	case *ast.CompositeLit:
-		return s.ParseExpr(expr.Type)
+		return s.parseCompositeLit(expr)
 
 	case *ast.InterfaceType:
 		return spec.NewObjectSchema().WithDescription("Any Type").WithExtendedType(spec.NewAnyExtendedType())
@@ -236,6 +251,13 @@ func (s *SchemaBuilder) parseStruct(expr *ast.StructType) *spec.SchemaRef {
 					schema.Required = append(schema.Required, propName)
 				}
 			}
+			if field.Tag != nil {
+				tags := tag.Parse(field.Tag.Value)
+				applyValidationTags(fieldSchema, tags)
+				if tag.Required(tags) && !lo.Contains(schema.Required, propName) {
+					schema.Required = append(schema.Required, propName)
+				}
+			}
 			schema.Properties[propName] = fieldSchema
 		}
 	}
@@ -244,46 +266,26 @@ func (s *SchemaBuilder) parseStruct(expr *ast.StructType) *spec.SchemaRef {
 }
 
 func (s *SchemaBuilder) parseIdent(expr *ast.Ident) *spec.SchemaRef {
+	if ctx, arg := s.ctx.ResolveArg(expr); arg != expr { // 函数参数使用调用处实参的类型
+		return newSchemaBuilderWithStack(ctx, s.contentType, s.stack).ParseExpr(arg)
+	}
 	t := s.ctx.Package().TypesInfo.TypeOf(expr)
 	if t == nil {
 		return nil
 	}
+	if obj := s.ctx.localVar(expr); obj != nil {
+		return s.parseLocalVar(obj, t)
+	}
 	return s.parseType(t)
 }
 
-var commonTypes = map[string]*spec.Schema{
-	"time.Time": spec.NewSchema().WithType("string").WithFormat("datetime"),
-	"encoding/json.RawMessage": spec.NewSchema().
-		WithType("object").
-		WithDescription("Any Json Type").
-		WithExtendedType(spec.NewAnyExtendedType()),
-	"json.RawMessage": spec.NewSchema().
-		WithType("object").
-		WithDescription("Any Json Type").
-		WithExtendedType(spec.NewAnyExtendedType()),
-	"database/sql.NullTime":    spec.NewDateTimeSchema(),
-	"database/sql.NullString":  spec.NewStringSchema(),
-	"database/sql.NullInt64":   spec.NewInt64Schema(),
-	"database/sql.NullInt32":   spec.NewInt32Schema(),
-	"database/sql.NullInt":     spec.NewIntegerSchema(),
-	"database/sql.NullInt16":   spec.NewIntegerSchema(),
-	"database/sql.NullFloat64": spec.NewFloat64Schema(),
-	"database/sql.NullBool":    spec.NewBoolSchema(),
-	"database/sql.NullByte":    spec.NewStringSchema(),
-}
-
 func (s *SchemaBuilder) commonUsedType(t types.Type) *spec.SchemaRef {
 	switch t := t.(type) {
 	case *types.Named:
 		if t.Obj() == nil || t.Obj().Pkg() == nil {
 			return nil
 		}
-		typeName := t.Obj().Pkg().Path() + "." + t.Obj().Name()
-		commonType, ok := commonTypes[typeName]
-		if !ok {
-			return nil
-		}
-		return commonType.Clone()
+		return s.ctx.analyzer.lookupTypeMapping(t.Obj().Pkg().Path() + "." + t.Obj().Name())
 
 	case *types.Pointer:
 		return s.commonUsedType(t.Elem())
@@ -292,6 +294,41 @@ func (s *SchemaBuilder) commonUsedType(t types.Type) *spec.SchemaRef {
 	return nil
 }
 
+// marshalerSchema 根据类型实现的序列化接口生成 schema. 与 encoding/json 一致, json.Marshaler 优先于 encoding.TextMarshaler.
+// 实现了 json.Marshaler 的类型无法确定序列化的结果, 使用任意类型; 实现了 encoding.TextMarshaler 的类型序列化为字符串.
+// 可以在类型注释中使用 @schema 或者通过 typeMappings 声明实际的 schema
+func marshalerSchema(t types.Type) *spec.Schema {
+	if hasMarshalMethod(t, "MarshalJSON") {
+		return spec.NewObjectSchema().
+			WithDescription("Any Json Type").
+			WithExtendedType(spec.NewAnyExtendedType())
+	}
+	if hasMarshalMethod(t, "MarshalText") {
+		return spec.NewStringSchema()
+	}
+	return nil
+}
+
+// hasMarshalMethod 判断类型 (或者其指针类型) 是否实现了签名为 func() ([]byte, error) 的方法
+func hasMarshalMethod(t types.Type, name string) bool {
+	if _, ok := t.(*types.Pointer); !ok {
+		t = types.NewPointer(t)
+	}
+	sel := types.NewMethodSet(t).Lookup(nil, name)
+	if sel == nil {
+		return false
+	}
+	sig, ok := sel.Type().(*types.Signature)
+	if !ok || sig.Params().Len() != 0 || sig.Results().Len() != 2 {
+		return false
+	}
+	bytes, ok := sig.Results().At(0).Type().(*types.Slice)
+	if !ok || !types.Identical(bytes.Elem(), types.Typ[types.Byte]) {
+		return false
+	}
+	return types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
+
 func (s *SchemaBuilder) parseSelectorExpr(expr *ast.SelectorExpr) *spec.SchemaRef {
 	return s.ParseExpr(expr.Sel)
 }
@@ -310,6 +347,10 @@ func (s *SchemaBuilder) getPropName(fieldName string, field *ast.Field, contentT
 	switch contentType {
 	case MimeTypeJson:
 		tagValue = tags["json"]
+		if value, ok := tags["protobuf"]; ok { // protoc-gen-go 生成的结构体使用 protojson 中的字段名
+			_, propName = tag.ParseProtobuf(value)
+			return
+		}
 	case MimeTypeXml, MimeApplicationXml:
 		tagValue = tags["xml"]
 	case MimeTypeFormData, MimeTypeFormUrlencoded:
@@ -391,6 +432,9 @@ func (s *SchemaBuilder) parseType(t types.Type) *spec.SchemaRef {
 	def := s.ctx.ParseType(t)
 	typeDef, ok := def.(*TypeDefinition)
 	if !ok {
+		if schema := marshalerSchema(t); schema != nil {
+			return schema
+		}
 		fmt.Fprintf(os.Stderr, "unknown type %s\n", t.String())
 		return spec.NewSchema().WithExtendedType(spec.NewUnknownExtType()).NewRef()
 	}
@@ -0,0 +1,48 @@
+package tag
+
+import "strings"
+
+// ValidationRule go-playground/validator 中的一条校验规则. e.g. `max=64` => {Name: "max", Param: "64"}
+type ValidationRule struct {
+	Name  string
+	Param string
+}
+
+// validationTags 存放校验规则的 tag. gin 使用 binding, validator 默认使用 validate
+var validationTags = []string{"binding", "validate"}
+
+// ParseValidationRules 解析 binding/validate tag 中的校验规则. dive 之后的规则作用于数组/map 的元素, 通过 elem 返回.
+// 使用 | 组合的规则 (满足任意一条即可) 无法转换为约束, 会被忽略
+func ParseValidationRules(tags map[string]string) (rules, elem []ValidationRule) {
+	for _, key := range validationTags {
+		value, ok := tags[key]
+		if !ok {
+			continue
+		}
+		target := &rules
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" || strings.Contains(item, "|") {
+				continue
+			}
+			if item == "dive" {
+				target = &elem
+				continue
+			}
+			name, param, _ := strings.Cut(item, "=")
+			*target = append(*target, ValidationRule{Name: name, Param: param})
+		}
+	}
+	return
+}
+
+// Required 判断 binding/validate tag 中的校验规则是否包含 required. e.g. `binding:"required,max=64"`
+func Required(tags map[string]string) bool {
+	rules, _ := ParseValidationRules(tags)
+	for _, rule := range rules {
+		if rule.Name == "required" {
+			return true
+		}
+	}
+	return false
+}
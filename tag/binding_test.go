@@ -0,0 +1,41 @@
+package tag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequired(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want bool
+	}{
+		{name: "required", tag: `binding:"required"`, want: true},
+		{name: "required with other rules", tag: `json:"name" binding:"max=64, required"`, want: true},
+		{name: "omitempty", tag: `binding:"omitempty,max=64"`, want: false},
+		{name: "required_if", tag: `binding:"required_if=Type 1"`, want: false},
+		{name: "no binding", tag: `json:"name"`, want: false},
+		{name: "validate", tag: `validate:"required,email"`, want: true},
+		{name: "required elements", tag: `binding:"min=1,dive,required"`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Required(Parse(tt.tag)); got != tt.want {
+				t.Errorf("Required() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseValidationRules(t *testing.T) {
+	rules, elem := ParseValidationRules(Parse(`json:"tags" binding:"required,min=1,dive,oneof=a b c" validate:"max=10|eq=0"`))
+	wantRules := []ValidationRule{{Name: "required"}, {Name: "min", Param: "1"}}
+	wantElem := []ValidationRule{{Name: "oneof", Param: "a b c"}}
+	if !reflect.DeepEqual(rules, wantRules) {
+		t.Errorf("ParseValidationRules() rules = %v, want %v", rules, wantRules)
+	}
+	if !reflect.DeepEqual(elem, wantElem) {
+		t.Errorf("ParseValidationRules() elem = %v, want %v", elem, wantElem)
+	}
+}
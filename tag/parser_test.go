@@ -51,3 +51,23 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParseProtobuf(t *testing.T) {
+	tests := []struct {
+		value        string
+		wantName     string
+		wantJsonName string
+	}{
+		{value: "bytes,3,opt,name=author_name,json=authorName,proto3", wantName: "author_name", wantJsonName: "authorName"},
+		{value: "varint,1,opt,name=id,proto3", wantName: "id", wantJsonName: "id"},
+		{value: "bytes,2,rep,name=books,proto3", wantName: "books", wantJsonName: "books"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			name, jsonName := ParseProtobuf(tt.value)
+			if name != tt.wantName || jsonName != tt.wantJsonName {
+				t.Errorf("ParseProtobuf() = (%v, %v), want (%v, %v)", name, jsonName, tt.wantName, tt.wantJsonName)
+			}
+		})
+	}
+}
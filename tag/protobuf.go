@@ -0,0 +1,21 @@
+package tag
+
+import "strings"
+
+// ParseProtobuf 解析 protoc-gen-go 生成的 protobuf tag, 返回字段在 proto 文件中的名字和 protojson 中使用的名字. e.g.
+//
+//	`protobuf:"bytes,3,opt,name=author_name,json=authorName,proto3"` => ("author_name", "authorName")
+func ParseProtobuf(value string) (name, jsonName string) {
+	for _, item := range strings.Split(value, ",") {
+		switch {
+		case strings.HasPrefix(item, "name="):
+			name = strings.TrimPrefix(item, "name=")
+		case strings.HasPrefix(item, "json="):
+			jsonName = strings.TrimPrefix(item, "json=")
+		}
+	}
+	if jsonName == "" {
+		jsonName = name
+	}
+	return
+}
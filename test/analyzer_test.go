@@ -4,11 +4,17 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	analyzer "github.com/gotomicro/eapi"
+	"github.com/gotomicro/eapi/plugins/chi"
 	"github.com/gotomicro/eapi/plugins/echo"
+	"github.com/gotomicro/eapi/plugins/ego"
+	"github.com/gotomicro/eapi/plugins/fiber"
 	"github.com/gotomicro/eapi/plugins/gin"
+	"github.com/gotomicro/eapi/plugins/grpcgateway"
+	"github.com/gotomicro/eapi/plugins/stdhttp"
 	"github.com/knadh/koanf"
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/file"
@@ -18,7 +24,7 @@ import (
 var plugins = map[string]analyzer.Plugin{}
 
 func init() {
-	var pluginList = []analyzer.Plugin{gin.NewPlugin(), echo.NewPlugin()}
+	var pluginList = []analyzer.Plugin{gin.NewPlugin(), echo.NewPlugin(), stdhttp.NewPlugin(), chi.NewPlugin(), fiber.NewPlugin(), grpcgateway.NewPlugin(), ego.NewPlugin()}
 	for _, plugin := range pluginList {
 		plugins[plugin.Name()] = plugin
 	}
@@ -50,6 +56,36 @@ func TestAnalyzer(t *testing.T) {
 				pkgPath: "./testdata/gin",
 			},
 		},
+		{
+			name: "stdhttp",
+			args: args{
+				pkgPath: "./testdata/stdhttp",
+			},
+		},
+		{
+			name: "chi",
+			args: args{
+				pkgPath: "./testdata/chi",
+			},
+		},
+		{
+			name: "fiber",
+			args: args{
+				pkgPath: "./testdata/fiber",
+			},
+		},
+		{
+			name: "grpcgateway",
+			args: args{
+				pkgPath: "./testdata/grpcgateway",
+			},
+		},
+		{
+			name: "ego",
+			args: args{
+				pkgPath: "./testdata/ego",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -64,7 +100,7 @@ func TestAnalyzer(t *testing.T) {
 			plugin, ok := plugins[config.Plugin]
 			assert.Truef(t, ok, "plugin %s not exists", config.Plugin)
 
-			a := analyzer.NewAnalyzer(k).Plugin(plugin).Depends(config.Depends...).Process(tt.args.pkgPath)
+			a := analyzer.NewAnalyzer(k).Plugin(plugin).Depends(config.Depends...).TypeMappings(config.TypeMappings...).Examples(config.Examples).Process(tt.args.pkgPath)
 			expectedDoc, err := os.ReadFile(filepath.Join(tt.args.pkgPath, "docs/openapi.json"))
 			assert.NoError(t, err)
 
@@ -76,3 +112,80 @@ func TestAnalyzer(t *testing.T) {
 		})
 	}
 }
+
+// annotationPlugin 声明了自定义注解的插件
+type annotationPlugin struct {
+	analyzer.Plugin
+	annotations []string
+}
+
+func (p *annotationPlugin) Annotations() []string {
+	return p.annotations
+}
+
+func TestDiagnostics(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkgPath string
+		// 插件声明的自定义注解
+		annotations []string
+		want        []string
+	}{
+		{
+			name:    "gin",
+			pkgPath: "./testdata/gin",
+			want: []string{
+				"shop.go:27:5: unknown annotation '@nullable'",
+				"shop.go:39:5: unknown annotation '@nullable'",
+			},
+		},
+		{
+			name:        "gin with plugin annotations",
+			pkgPath:     "./testdata/gin",
+			annotations: []string{"@nullable"},
+		},
+		{
+			name:    "echo",
+			pkgPath: "./testdata/echo",
+			want: []string{
+				"goods.go:28:6: unknown type 'Audio' in @oneOf",
+				"goods.go:69:4: invalid type 'model.NoSuchEvent' in @callback: undefined: model.NoSuchEvent",
+				"goods.go:68:4: invalid type 'NoSuchType' in @response: undefined: NoSuchType",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := koanf.New(".")
+			err := k.Load(file.Provider(filepath.Join(tt.pkgPath, "eapi.yaml")), yaml.Parser())
+			assert.NoError(t, err)
+
+			var config analyzer.Config
+			err = k.Unmarshal("", &config)
+			assert.NoError(t, err)
+
+			plugin := &annotationPlugin{Plugin: plugins[config.Plugin], annotations: tt.annotations}
+			a := analyzer.NewAnalyzer(k).Plugin(plugin).Depends(config.Depends...).Annotations(config.Annotations...).Process(tt.pkgPath)
+			var messages []string
+			for _, d := range a.Diagnostics() {
+				messages = append(messages, filepath.Base(d.Position.Filename)+":"+strconv.Itoa(d.Position.Line)+":"+strconv.Itoa(d.Position.Column)+": "+d.Message)
+			}
+			assert.Equal(t, tt.want, messages)
+		})
+	}
+}
+
+func TestProduction(t *testing.T) {
+	pkgPath := "./testdata/stdhttp"
+	k := koanf.New(".")
+	err := k.Load(file.Provider(filepath.Join(pkgPath, "eapi.yaml")), yaml.Parser())
+	assert.NoError(t, err)
+
+	var config analyzer.Config
+	err = k.Unmarshal("", &config)
+	assert.NoError(t, err)
+
+	doc := analyzer.NewAnalyzer(k).Plugin(plugins[config.Plugin]).Production(true).Process(pkgPath).Doc()
+	assert.Nil(t, doc.Paths["/debug"])
+	assert.NotNil(t, doc.Paths["/healthz"])
+}
@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const accountsPath = "/accounts"
+
+// Router 管理后台路由
+// @tags Admin
+func Router() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/", Dashboard)
+	r.Get(accountsPath+"/{accountID}", GetAccount)
+	return r
+}
+
+// Dashboard 管理后台首页
+func Dashboard(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("admin"))
+}
+
+// GetAccount 获取账号
+func GetAccount(w http.ResponseWriter, r *http.Request) {
+	// Account ID
+	_ = chi.URLParam(r, "accountID")
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,5 @@
+module chiserver
+
+go 1.20
+
+require github.com/go-chi/chi/v5 v5.0.8
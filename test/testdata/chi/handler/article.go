@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"chiserver/model"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ArticleHandler struct{}
+
+func NewArticleHandler() *ArticleHandler {
+	return &ArticleHandler{}
+}
+
+func Paginate(next http.Handler) http.Handler {
+	return next
+}
+
+// List 文章列表
+func (h *ArticleHandler) List(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]*model.Article{})
+}
+
+// Search 搜索文章
+func (h *ArticleHandler) Search(w http.ResponseWriter, r *http.Request) {
+	// Search keyword
+	_ = r.URL.Query().Get("q")
+	json.NewEncoder(w).Encode([]*model.Article{})
+}
+
+// Create 创建文章
+func (h *ArticleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.ArticleRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(&model.Article{})
+}
+
+// Get 文章详情
+func (h *ArticleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	// Article ID
+	_ = chi.URLParam(r, "articleID")
+	json.NewEncoder(w).Encode(&model.Article{})
+}
+
+// Update 更新文章
+func (h *ArticleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	_ = chi.URLParam(r, "articleID")
+	var req model.ArticleRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	json.NewEncoder(w).Encode(&model.Article{})
+}
+
+// Delete 删除文章
+func (h *ArticleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	_ = chi.URLParamFromCtx(r.Context(), "articleID")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Tags 标签列表
+func (h *ArticleHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode([]string{})
+}
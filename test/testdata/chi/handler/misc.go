@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"chiserver/model"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Ping 健康检查
+func Ping(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("pong"))
+}
+
+// Stats 统计信息
+func Stats(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]int{})
+}
+
+// GetUser 用户详情
+func GetUser(w http.ResponseWriter, r *http.Request) {
+	_ = chi.URLParam(r, "userID")
+	json.NewEncoder(w).Encode(&model.User{})
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"net/http"
+
+	"chiserver/admin"
+	"chiserver/handler"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	apiPrefix   = "/api"
+	adminPrefix = "/admin"
+)
+
+func main() {
+	r := chi.NewRouter()
+	h := handler.NewArticleHandler()
+
+	r.Get("/ping", handler.Ping)
+
+	// @tags Article
+	{
+		r.Route("/articles", func(r chi.Router) {
+			r.Get("/", h.List)
+			r.With(handler.Paginate).Get("/search", h.Search)
+			r.Post("/", h.Create)
+
+			r.Route("/{articleID:[0-9]+}", func(r chi.Router) {
+				r.Get("/", h.Get)
+				r.Put("/", h.Update)
+				r.Method(http.MethodDelete, "/", http.HandlerFunc(h.Delete))
+			})
+		})
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Use(handler.Paginate)
+		r.Get("/tags", h.Tags)
+	})
+
+	r.Route("/users", userRoutes)
+
+	api := r.Route(apiPrefix+"/v1", nil)
+	api.Get("/stats", handler.Stats)
+
+	r.Mount(adminPrefix, admin.Router())
+
+	_ = http.ListenAndServe(":3000", r)
+}
+
+// @tags User
+func userRoutes(r chi.Router) {
+	r.Get("/{userID}", handler.GetUser)
+}
@@ -0,0 +1,19 @@
+package model
+
+type Article struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	// Article content
+	Content string `json:"content"`
+}
+
+type ArticleRequest struct {
+	// @required
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -2,8 +2,9 @@ module sample
 
 go 1.19
 
+require github.com/labstack/echo/v4 v4.9.1
+
 require (
-	github.com/labstack/echo/v4 v4.9.1 // indirect
 	github.com/labstack/gommon v0.4.0 // indirect
 	github.com/mattn/go-colorable v0.1.11 // indirect
 	github.com/mattn/go-isatty v0.0.14 // indirect
@@ -18,6 +18,25 @@ type GoodsInfo struct {
 	Cover string `json:"cover"`
 	// Detail images
 	Images []*Image `json:"images"`
+	// Media resources
+	Medias []Media `json:"medias"`
+}
+
+// Media 商品媒体资源
+// @oneOf Picture Video Audio
+// @discriminator type
+type Media interface{}
+
+type Picture struct {
+	Type string `json:"type" validate:"oneof=picture"`
+	URL  string `json:"url"`
+}
+
+type Video struct {
+	Type string `json:"type" validate:"oneof=video"`
+	URL  string `json:"url"`
+	// 时长 (秒)
+	Duration int `json:"duration"`
 }
 
 type Image struct {
@@ -29,7 +48,7 @@ type ListGoodsRequest struct {
 	// Obtained in `nextPage` field of previous page response.
 	Since string `query:"since"`
 	// @required
-	Limit int64 `query:"limit"`
+	Limit int64 `query:"limit" validate:"min=1,max=100"`
 }
 
 type ListGoodsResponse struct {
@@ -39,13 +58,17 @@ type ListGoodsResponse struct {
 }
 
 type CreateGoodsRequest struct {
-	Title  string      `json:"title"`
+	Title  string      `json:"title" validate:"required,min=1,max=64"`
 	Status GoodsStatus `json:"status"`
-	Stock  int         `json:"stock"`
+	Stock  int         `json:"stock" validate:"gte=0"`
 	// Url of cover image
-	Cover string `json:"cover"`
+	Cover string `json:"cover" validate:"omitempty,url"`
 	// Detail images
-	Images []*Image `json:"images"`
+	Images []*Image `json:"images" validate:"min=1,max=9,dive,required"`
+	Tags   []string `json:"tags" validate:"max=5,dive,oneof=new hot 'on sale'"`
+	// Seller contact email
+	Email string `json:"email" validate:"omitempty,email"`
+	Sku   string `json:"sku" validate:"startswith=SKU-,alphanum|contains=-"`
 }
 
 type UpdateGoodsRequest struct {
@@ -88,3 +111,9 @@ type MultipleParamGeneric[T, V any] struct {
 type Error struct {
 	Message string `json:"message"`
 }
+
+type RequestHeader struct {
+	// 租户 ID
+	// @required
+	TenantID string `header:"X-Tenant-ID"`
+}
@@ -1,15 +1,31 @@
 package main
 
 import (
+	"net/http"
+
+	"sample/model"
+	"sample/v1/adapter"
+	"sample/v1/category"
 	"sample/v1/goods"
+	"sample/v1/session"
 	"sample/v1/uploader"
 
 	"github.com/labstack/echo/v4"
 )
 
+const apiPrefix = "/v1"
+
+var stockRoutes = []struct {
+	method  string
+	path    string
+	handler echo.HandlerFunc
+}{
+	{http.MethodGet, "/goods/:id/stock", goods.Stock},
+}
+
 func main() {
 	e := echo.New()
-	v1 := e.Group("/v1")
+	v1 := e.Group(apiPrefix)
 
 	// Uploader
 	{
@@ -22,7 +38,28 @@ func main() {
 		v1.POST("/goods", goods.Create)
 		v1.PATCH("/goods", goods.Update)
 		v1.DELETE("/goods/:id", goods.Delete)
+		v1.GET("/goods/export", goods.Export)
+		v1.POST("/goods/:id/archive", goods.Archive)
+		v1.GET("/goods/:id/page", goods.Page)
+		v1.GET("/goods/:id/cover", goods.Cover)
+		v1.GET("/goods/:id/similar", goods.Similar)
+	}
+
+	// Adapter
+	{
+		svc := &goods.Service{}
+		v1.GET("/goods/search", adapter.Handle[model.ListGoodsRequest, model.ListGoodsResponse](svc.Search))
+		v1.PUT("/goods", adapter.Logged(adapter.Handle(svc.Replace)))
+	}
+
+	category.Register(v1)
+	v1.POST("/session", session.Login)
+
+	// Route table
+	for _, r := range stockRoutes {
+		v1.Add(r.method, r.path, r.handler)
 	}
+	e.Any("/ping", goods.Ping)
 
 	e.Start(":8081")
 }
@@ -0,0 +1,31 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Handle 将 func(ctx, *Req) (*Res, error) 形式的业务方法转换为 echo.HandlerFunc
+func Handle[Req any, Res any](fn func(ctx context.Context, req *Req) (*Res, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req Req
+		if err := c.Bind(&req); err != nil {
+			return err
+		}
+		res, err := fn(c.Request().Context(), &req)
+		if err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+}
+
+// Logged 记录请求日志
+func Logged(h echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Logger().Info(c.Request().URL.Path)
+		return h(c)
+	}
+}
@@ -0,0 +1,34 @@
+package category
+
+import (
+	"net/http"
+
+	"sample/v1/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Category struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type Router struct {
+	group *echo.Group
+}
+
+func Register(g *echo.Group) {
+	r := &Router{}
+	r.group = g.Group("/categories", middleware.KeyAuth)
+	r.register()
+}
+
+func (r *Router) register() {
+	r.group.GET("", r.list)
+}
+
+// list
+// @summary List Categories
+func (r *Router) list(c echo.Context) error {
+	return c.JSON(http.StatusOK, []Category{})
+}
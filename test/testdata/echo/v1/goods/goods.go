@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"sample/model"
+	"sample/v1/render"
 
 	"github.com/labstack/echo/v4"
 )
@@ -18,6 +19,12 @@ func List(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	var header model.RequestHeader
+	if err := (&echo.DefaultBinder{}).BindHeaders(c, &header); err != nil {
+		return err
+	}
+	// 请求 ID
+	_ = c.Request().Header.Get("X-Request-ID")
 
 	c.JSON(http.StatusOK, model.ListGoodsResponse{})
 	return nil
@@ -58,6 +65,8 @@ func updateGoods[T any](val T) []T {
 // Delete
 // @tags Goods
 // @summary Delete Goods
+// @response 404 NoSuchType
+// @callback deleted post {$request.query.callback} model.NoSuchEvent
 func Delete(c echo.Context) error {
 	goodsId := c.Param("id")
 	_ = goodsId
@@ -73,3 +82,49 @@ func deleteGoods(context context.Context, id string) model.GenericTypeResponse[s
 		Metadata: map[string]any{},
 	}
 }
+
+// Export
+// @tags Goods
+// @summary Export Goods
+func Export(c echo.Context) error {
+	if c.QueryParam("format") != "csv" {
+		// 不支持的导出格式
+		return c.String(http.StatusNotAcceptable, "unsupported format")
+	}
+	// 商品 CSV 文件
+	return c.Blob(http.StatusOK, "text/csv", []byte{})
+}
+
+// Archive
+// @tags Goods
+// @summary Archive Goods
+func Archive(c echo.Context) error {
+	_ = c.Param("id")
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Page
+// @tags Goods
+// @summary Goods Detail Page
+func Page(c echo.Context) error {
+	_ = c.Param("id")
+	return c.HTML(http.StatusOK, "<h1>goods</h1>")
+}
+
+// Cover
+// @tags Goods
+// @summary Goods Cover
+func Cover(c echo.Context) error {
+	return c.Attachment("./covers/"+c.Param("id")+".png", "cover.png")
+}
+
+// Similar
+// @tags Goods
+// @summary Similar Goods
+func Similar(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return render.Error(c, http.StatusNotFound, "goods not found")
+	}
+	return render.OK(c, []model.GoodsInfo{})
+}
@@ -0,0 +1,19 @@
+package goods
+
+import (
+	"context"
+
+	"sample/model"
+)
+
+type Service struct{}
+
+// Search Search goods
+func (s *Service) Search(ctx context.Context, req *model.ListGoodsRequest) (*model.ListGoodsResponse, error) {
+	return &model.ListGoodsResponse{}, nil
+}
+
+// Replace Replace goods
+func (s *Service) Replace(ctx context.Context, req *model.CreateGoodsRequest) (*model.GoodsInfo, error) {
+	return &model.GoodsInfo{}, nil
+}
@@ -0,0 +1,24 @@
+package goods
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type StockResponse struct {
+	Stock int `json:"stock"`
+}
+
+// Stock
+// @tags Goods
+// @summary Goods Stock
+func Stock(c echo.Context) error {
+	return c.JSON(http.StatusOK, StockResponse{})
+}
+
+// Ping
+// @summary Health Check
+func Ping(c echo.Context) error {
+	return c.String(http.StatusOK, "pong")
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+// KeyAuth 校验 API Key
+func KeyAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		// API Key
+		key := c.QueryParam("api_key")
+		if key == "" {
+			// API Key 无效
+			return c.JSON(http.StatusUnauthorized, Error{Message: "invalid api key"})
+		}
+		return next(c)
+	}
+}
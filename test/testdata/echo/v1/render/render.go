@@ -0,0 +1,26 @@
+package render
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Result 统一响应结构
+type Result struct {
+	// 提示信息
+	Message string `json:"message"`
+	// 业务数据
+	Data any `json:"data,omitempty"`
+}
+
+// OK 成功响应
+func OK(c echo.Context, data any) error {
+	return c.JSON(http.StatusOK, Result{Message: "ok", Data: data})
+}
+
+// Error 错误响应
+func Error(c echo.Context, code int, message string) error {
+	// 请求失败
+	return c.JSON(code, Result{Message: message})
+}
@@ -0,0 +1,20 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Session struct {
+	UserID int64 `json:"userId"`
+}
+
+// Login 登录
+func Login(c echo.Context) error {
+	// 设备 ID
+	_, _ = c.Cookie("device_id")
+	cookie := &http.Cookie{Name: "session_id", Value: "xxx", HttpOnly: true}
+	c.SetCookie(cookie)
+	return c.JSON(http.StatusOK, Session{})
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context 业务中使用的 gin.Context 封装
+type Context struct {
+	*gin.Context
+}
+
+// Res 统一的响应格式
+type Res struct {
+	Code int         `json:"code"`
+	Msg  string      `json:"msg"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// HandlerFunc 业务 handler
+type HandlerFunc func(c *Context)
+
+// Handle 将业务 handler 转换为 gin.HandlerFunc
+func Handle(h HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h(&Context{Context: c})
+	}
+}
+
+// JSONOK 返回成功
+func (c *Context) JSONOK(data ...interface{}) {
+	j := new(Res)
+	j.Code = 0
+	j.Msg = "ok"
+	if len(data) > 0 {
+		j.Data = data[0]
+	}
+	c.JSON(http.StatusOK, j)
+}
+
+// JSONE 返回错误
+func (c *Context) JSONE(code int, msg string, data interface{}) {
+	c.JSON(http.StatusOK, Res{
+		Code: code,
+		Msg:  msg,
+		Data: data,
+	})
+}
+
+// Bind 绑定请求参数
+func (c *Context) Bind(obj interface{}) error {
+	return c.ShouldBind(obj)
+}
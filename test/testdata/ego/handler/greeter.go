@@ -0,0 +1,15 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	helloworldv1 "egoserver/gen/helloworld/v1"
+)
+
+type Greeter struct{}
+
+// SayHello 打招呼
+func (g *Greeter) SayHello(ctx context.Context, req *helloworldv1.HelloRequest) (*helloworldv1.HelloReply, error) {
+	return &helloworldv1.HelloReply{Message: "hello " + req.Name, CreateTime: time.Now().Unix()}, nil
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"egoserver/core"
+	"egoserver/model"
+)
+
+// UserInfo 获取用户信息
+func UserInfo(c *core.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSONE(1, "invalid id", nil)
+		return
+	}
+	c.JSONOK(model.User{})
+}
+
+// UserList 用户列表
+func UserList(c *core.Context) {
+	var req model.ListUserReq
+	if err := c.Bind(&req); err != nil {
+		c.JSONE(1, err.Error(), nil)
+		return
+	}
+	c.JSONOK([]model.User{})
+}
+
+// UserCreate 创建用户
+// @consume application/json
+func UserCreate(c *core.Context) {
+	var req model.CreateUserReq
+	if err := c.Bind(&req); err != nil {
+		c.JSONE(1, err.Error(), nil)
+		return
+	}
+	c.JSONOK(model.User{Nickname: req.Nickname, Avatar: req.Avatar})
+}
+
+// UserDelete 删除用户
+func UserDelete(c *core.Context) {
+	c.JSONOK()
+}
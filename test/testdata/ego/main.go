@@ -0,0 +1,30 @@
+package main
+
+import (
+	"egoserver/core"
+	"egoserver/handler"
+
+	"github.com/gotomicro/ego"
+	"github.com/gotomicro/ego/server/egin"
+)
+
+func main() {
+	if err := ego.New().Serve(serveHTTP()).Run(); err != nil {
+		panic(err)
+	}
+}
+
+func serveHTTP() *egin.Component {
+	server := egin.Load("server.http").Build()
+
+	greeter := &handler.Greeter{}
+	server.GET("/api/hello", egin.GRPCProxy(greeter.SayHello))
+	server.POST("/api/hello", egin.GRPCProxy(greeter.SayHello))
+
+	g := server.Group("/api/users")
+	g.GET("", core.Handle(handler.UserList))
+	g.POST("", core.Handle(handler.UserCreate))
+	g.GET("/:id", core.Handle(handler.UserInfo))
+	g.DELETE("/:id", core.Handle(handler.UserDelete))
+	return server
+}
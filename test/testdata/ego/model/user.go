@@ -0,0 +1,18 @@
+package model
+
+type User struct {
+	ID       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+}
+
+type CreateUserReq struct {
+	// 昵称
+	Nickname string `json:"nickname" form:"nickname"`
+	Avatar   string `json:"avatar" form:"avatar"`
+}
+
+type ListUserReq struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fiberserver/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GoodsHandler struct{}
+
+func NewGoodsHandler() *GoodsHandler {
+	return &GoodsHandler{}
+}
+
+func Auth(c *fiber.Ctx) error {
+	return c.Next()
+}
+
+// List 商品列表
+func (h *GoodsHandler) List(c *fiber.Ctx) error {
+	var params model.ListGoodsParams
+	if err := c.QueryParser(&params); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(&model.Error{})
+	}
+	return c.JSON([]*model.Goods{})
+}
+
+// Create 创建商品
+func (h *GoodsHandler) Create(c *fiber.Ctx) error {
+	var req model.GoodsRequest
+	if err := c.BodyParser(&req); err != nil {
+		// Invalid request body
+		return c.Status(fiber.StatusBadRequest).JSON(&model.Error{})
+	}
+	c.Status(fiber.StatusCreated)
+	return c.JSON(&model.Goods{})
+}
+
+// Get 商品详情
+func (h *GoodsHandler) Get(c *fiber.Ctx) error {
+	// Goods ID
+	_ = c.Params("id")
+	return c.JSON(&model.Goods{})
+}
+
+// Update 更新商品
+func (h *GoodsHandler) Update(c *fiber.Ctx) error {
+	_ = c.Params("id")
+	var req model.GoodsRequest
+	_ = c.BodyParser(&req)
+	return c.JSON(&model.Goods{})
+}
+
+// Delete 删除商品
+func (h *GoodsHandler) Delete(c *fiber.Ctx) error {
+	_ = c.Params("id")
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Upload 上传商品图片
+func (h *GoodsHandler) Upload(c *fiber.Ctx) error {
+	_ = c.Params("id")
+	// Image file
+	_, _ = c.FormFile("file")
+	// Image description
+	_ = c.FormValue("description")
+	return c.XML(&model.Goods{})
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Ping 健康检查
+func Ping(c *fiber.Ctx) error {
+	return c.SendString("pong")
+}
+
+// Search 搜索
+func Search(c *fiber.Ctx) error {
+	_ = c.Params("keyword")
+	// Page number
+	_ = c.Query("page", "1")
+	return c.JSON([]string{})
+}
+
+// Login 登录
+func Login(c *fiber.Ctx) error {
+	// 设备 ID
+	_ = c.Cookies("device_id")
+	c.Cookie(&fiber.Cookie{Name: "session_id", Value: "xxx"})
+	return c.JSON(fiber.Map{})
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+
+	"fiberserver/handler"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	apiPrefix = "/api"
+	idParam   = "/:id"
+)
+
+func main() {
+	app := fiber.New()
+	h := handler.NewGoodsHandler()
+
+	app.Get("/ping", handler.Ping)
+
+	// @tags Goods
+	{
+		api := app.Group(apiPrefix + "/v1")
+		goods := api.Group("/goods")
+		goods.Get("/", h.List)
+		goods.Post("/", handler.Auth, h.Create)
+		goods.Get(idParam, h.Get)
+		goods.Put("/:id", h.Update)
+		goods.Add(http.MethodDelete, "/:id", h.Delete)
+		goods.Post("/:id/image", h.Upload)
+	}
+
+	// @tags Misc
+	app.Get("/search/:keyword?", handler.Search)
+	app.Post("/session", handler.Login)
+
+	_ = app.Listen(":3000")
+}
@@ -0,0 +1,25 @@
+package model
+
+type Goods struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	// Price in cents
+	Price int64 `json:"price"`
+}
+
+type GoodsRequest struct {
+	// @required
+	Title string `json:"title"`
+	Price int64  `json:"price"`
+}
+
+type ListGoodsParams struct {
+	Page     int    `query:"page"`
+	PageSize int    `query:"page_size"`
+	Keyword  string `query:"keyword"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
@@ -2,7 +2,10 @@ module server
 
 go 1.18
 
-require github.com/gin-gonic/gin v1.8.1
+require (
+	github.com/gin-gonic/gin v1.8.1
+	gorm.io/gorm v1.24.5
+)
 
 require (
 	github.com/gin-contrib/sse v0.1.0 // indirect
@@ -27,5 +30,4 @@ require (
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	google.golang.org/protobuf v1.28.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gorm.io/gorm v1.24.5 // indirect
 )
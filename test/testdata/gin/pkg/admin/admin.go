@@ -0,0 +1,12 @@
+package admin
+
+import (
+	"server/pkg/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Setup 管理后台路由. 复用了用户相关路由
+func Setup(_ *gin.Engine, g *gin.RouterGroup) {
+	user.RegisterRoutes(g.Group("/users"))
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handle 将 func(ctx, *Req) (*Res, error) 形式的业务方法转换为 gin.HandlerFunc
+func Handle[Req any, Res any](fn func(ctx context.Context, req *Req) (*Res, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req Req
+		if err := c.ShouldBind(&req); err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		res, err := fn(c.Request.Context(), &req)
+		if err != nil {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, res)
+	}
+}
+
+// Auth 校验登录状态
+func Auth(h gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		h(c)
+	}
+}
+
+// Recover 捕获 panic
+func Recover(h gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if err := recover(); err != nil {
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+		h(c)
+	}
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// AuthRequired 登录校验
+// @security oauth2 goods:write
+func AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 访问令牌
+		// @required
+		token := c.GetHeader("Authorization")
+		if token == "" {
+			// 未登录
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{Code: 401, Msg: "unauthorized"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Tenant 多租户
+func Tenant(c *gin.Context) {
+	// 租户 ID
+	_ = c.Query("tenant")
+	c.Next()
+}
@@ -0,0 +1,34 @@
+package resp
+
+import (
+	"net/http"
+
+	"server/pkg/view"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Response 统一响应结构
+type Response struct {
+	// 业务状态码
+	Code int `json:"code"`
+	// 提示信息
+	Msg string `json:"msg"`
+	// 业务数据
+	Data interface{} `json:"data"`
+}
+
+// OK 成功响应
+func OK(c *gin.Context, data interface{}) {
+	JSON(c, http.StatusOK, Response{Msg: "ok", Data: data})
+}
+
+// Error 错误响应
+func Error(c *gin.Context, status int, err *view.Error) {
+	c.AbortWithStatusJSON(status, Response{Code: int(err.Code), Msg: err.Msg})
+}
+
+// JSON 输出 JSON 响应
+func JSON(c *gin.Context, status int, body interface{}) {
+	c.JSON(status, body)
+}
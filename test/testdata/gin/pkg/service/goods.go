@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"server/pkg/view"
+)
+
+type GoodsService struct{}
+
+type GoodsSearchReq struct {
+	// 关键词
+	Keyword string `form:"keyword"`
+	Page    int    `form:"page"`
+}
+
+type GoodsSearchRes struct {
+	List  []*view.GoodsInfoRes `json:"list"`
+	Total int64                `json:"total"`
+}
+
+// Search 搜索商品
+func (s *GoodsService) Search(ctx context.Context, req *GoodsSearchReq) (*GoodsSearchRes, error) {
+	return &GoodsSearchRes{}, nil
+}
+
+// Update 更新商品
+// @consume application/json
+func (s *GoodsService) Update(ctx context.Context, req *view.GoodsCreateReq) (*view.GoodsCreateRes, error) {
+	return &view.GoodsCreateRes{}, nil
+}
@@ -2,9 +2,11 @@ package shop
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"server/pkg/handler"
+	"server/pkg/resp"
 	"server/pkg/view"
 
 	"github.com/gin-gonic/gin"
@@ -14,6 +16,8 @@ import (
 // @summary 创建商品
 // @consume application/json
 // @produce application/json
+// @example request {"title": "iPhone 15", "price": 599900}
+// @example response 400 {"code": 10000, "msg": "invalid_argument"}
 func GoodsCreate(c *handler.CustomContext) {
 	var req view.GoodsCreateReq
 	if err := c.Bind(&req); err != nil {
@@ -81,3 +85,178 @@ func WrappedHandler(c *handler.CustomContext) {
 	// 自定义响应函数
 	c.JSONOK(map[string]interface{}{})
 }
+
+// GoodsUp 上架商品
+func GoodsUp(c *gin.Context) {
+	guid := c.Param("guid")
+	c.JSON(http.StatusOK, view.GoodsInfoRes{Title: guid})
+}
+
+type GoodsStockRes struct {
+	Stock int64 `json:"stock"`
+}
+
+// GoodsStock 商品库存
+func GoodsStock(c *gin.Context) {
+	c.JSON(http.StatusOK, GoodsStockRes{})
+}
+
+type GoodsStockUpdateReq struct {
+	Stock int64 `json:"stock"`
+}
+
+// GoodsStockUpdate 更新商品库存
+// @consume application/json
+func GoodsStockUpdate(c *gin.Context) {
+	var req GoodsStockUpdateReq
+	_ = c.ShouldBindJSON(&req)
+	c.JSON(http.StatusOK, GoodsStockRes{Stock: req.Stock})
+}
+
+// GoodsHot 热门商品
+// @audit goods
+func GoodsHot(c *gin.Context) {
+	c.JSON(http.StatusOK, []view.GoodsInfoRes{})
+}
+
+// Ping 健康检查
+func Ping(c *gin.Context) {
+	c.String(http.StatusOK, "pong")
+}
+
+// GoodsRecommend 推荐商品
+func GoodsRecommend(c *gin.Context) {
+	var header GoodsRecommendHeader
+	_ = c.ShouldBindHeader(&header)
+	// 请求 ID
+	_ = c.Request.Header.Get("X-Request-ID")
+	c.JSON(http.StatusOK, []view.GoodsInfoRes{})
+}
+
+type GoodsRecommendHeader struct {
+	// 租户 ID
+	TenantID string `header:"X-Tenant-ID"`
+	Platform string
+}
+
+type GoodsComment struct {
+	Content string `json:"content"`
+}
+
+type GoodsCommentsReq struct {
+	Guid string `uri:"guid"`
+	// 页码
+	Page int `form:"page" binding:"required,gt=0"`
+	// 语言
+	Lang string `header:"Accept-Language"`
+}
+
+// GoodsComments 商品评论
+// @param page query integer 页码, 从 1 开始
+func GoodsComments(c *gin.Context) {
+	var req GoodsCommentsReq
+	_ = c.ShouldBind(&req)
+	c.JSON(http.StatusOK, []GoodsComment{})
+}
+
+type GoodsPriceUpdateReq struct {
+	Guid  string `uri:"guid" binding:"required"`
+	Token string `header:"X-Token" binding:"required"`
+	// 调价来源
+	Source string `form:"source" binding:"omitempty,alphanum,max=32"`
+	// 价格 (分)
+	Price    int64  `json:"price" binding:"required,min=1"`
+	Currency string `json:"currency" binding:"omitempty,oneof=CNY USD"`
+}
+
+// GoodsPriceUpdate 修改商品价格
+func GoodsPriceUpdate(c *gin.Context) {
+	var req GoodsPriceUpdateReq
+	if err := c.ShouldBind(&req); err != nil {
+		return
+	}
+	c.JSON(http.StatusOK, view.GoodsInfoRes{})
+}
+
+// GoodsExport 导出商品
+// @param format query string required 导出格式, 目前只支持 csv
+// @param fields query []string 导出的字段
+// @externalDocs https://example.com/docs/export "导出格式说明"
+// @server https://export.example.com 导出服务
+// @header 200 X-Export-Count integer 导出的商品数量
+// @callback exported post {$request.query.callback} view.GoodsInfoRes
+// @x-rate-limit {"rate": 10, "burst": 20}
+func GoodsExport(c *gin.Context) {
+	_ = exportFields(c)
+	if c.Query("format") != "csv" {
+		// 不支持的导出格式
+		c.AbortWithStatus(http.StatusNotAcceptable)
+		return
+	}
+	// 商品 CSV 文件
+	c.Data(http.StatusOK, "text/csv", []byte{})
+}
+
+func exportFields(c *gin.Context) []string {
+	return c.Request.URL.Query()["fields"]
+}
+
+// GoodsArchive 归档商品
+func GoodsArchive(c *gin.Context) {
+	if c.Param("guid") == "" {
+		// 商品已归档
+		c.AbortWithError(http.StatusConflict, errors.New("goods archived"))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GoodsPage 商品详情页
+func GoodsPage(c *gin.Context) {
+	guid := c.Param("guid")
+	if guid == "" {
+		c.String(http.StatusNotFound, "goods %s not found", guid)
+		return
+	}
+	c.HTML(http.StatusOK, "goods.tmpl", gin.H{"guid": guid})
+}
+
+// GoodsCover 商品封面
+func GoodsCover(c *gin.Context) {
+	c.File("./covers/" + c.Param("guid") + ".png")
+}
+
+// GoodsSimilar 相似商品
+func GoodsSimilar(c *gin.Context) {
+	guid := c.Param("guid")
+	if guid == "" {
+		resp.Error(c, http.StatusBadRequest, view.ErrInvalidArgument)
+		return
+	}
+	resp.OK(c, []view.GoodsInfoRes{})
+}
+
+// GoodsSearch 搜索商品
+func GoodsSearch(c *gin.Context) {
+	var data interface{}
+	if c.Query("guid") != "" {
+		data = view.GoodsInfoRes{}
+	} else {
+		data = []view.GoodsInfoRes{}
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+// GoodsBrief 商品简介
+func GoodsBrief(c *gin.Context) {
+	res := resp.Response{Msg: "ok"}
+	res.Data = view.GoodsInfoRes{}
+	c.JSON(http.StatusOK, res)
+}
+
+// GoodsPromotions 商品促销活动
+// @response 404 view.Error "商品不存在"
+// @response default *view.Error "未知错误"
+func GoodsPromotions(c *gin.Context) {
+	c.JSON(http.StatusOK, view.GoodsPromotionsRes{Guid: c.Param("guid")})
+}
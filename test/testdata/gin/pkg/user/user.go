@@ -0,0 +1,57 @@
+package user
+
+import (
+	"net/http"
+
+	"server/pkg/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+type User struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// RegisterRoutes 用户相关路由
+func RegisterRoutes(g *gin.RouterGroup) {
+	g.GET("/:id", middleware.Tenant, Info)
+
+	h := &Handler{router: g.Group("/admin")}
+	h.Register()
+}
+
+// Info 用户详情
+func Info(c *gin.Context) {
+	_ = c.Param("id")
+	c.JSON(http.StatusOK, User{})
+}
+
+type Handler struct {
+	router *gin.RouterGroup
+}
+
+func (h *Handler) Register() {
+	h.router.DELETE("/:id", h.Delete)
+}
+
+// Delete 删除用户
+func (h *Handler) Delete(c *gin.Context) {
+	_ = c.Param("id")
+	c.Status(http.StatusNoContent)
+}
+
+type LoginReq struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// Login 登录
+func Login(c *gin.Context) {
+	var req LoginReq
+	_ = c.ShouldBindJSON(&req)
+	// 设备 ID
+	_, _ = c.Cookie("device_id")
+	c.SetCookie("session_id", "xxx", 3600, "/", "", true, true)
+	c.JSON(http.StatusOK, User{})
+}
@@ -2,12 +2,15 @@ package view
 
 import (
 	"encoding/json"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // Image 商品图片
+// @example {"url": "https://example.com/goods.png"}
 type Image struct {
 	// 图片链接
 	// @required
@@ -17,6 +20,7 @@ type Image struct {
 type GoodsCreateReq struct {
 	// 商品标题
 	// @required
+	// @example iPhone 15
 	Title string `json:"title"`
 
 	// 商品描述
@@ -28,6 +32,7 @@ type GoodsCreateReq struct {
 
 	// 价格(分)
 	// @required
+	// @example 599900
 	Price int64 `json:"price"`
 
 	// 详情图
@@ -77,3 +82,67 @@ type GoodsDownRes struct {
 type GoodsDeleteRequest struct {
 	FormDataField string `form:"formDataField"`
 }
+
+// Promotion 促销活动
+type Promotion interface {
+	promotion()
+}
+
+// Discount 折扣活动
+type Discount struct {
+	Kind string `json:"kind" binding:"oneof=discount"`
+	// 折扣率
+	Rate float64 `json:"rate"`
+}
+
+func (Discount) promotion() {}
+
+// Coupon 优惠券活动
+type Coupon struct {
+	Kind string `json:"kind" binding:"oneof=coupon"`
+	// 优惠金额
+	Amount Money `json:"amount"`
+	// 过期时间
+	ExpiredAt NullableTime `json:"expiredAt"`
+	// 适用范围
+	Scope CouponScope `json:"scope"`
+}
+
+func (*Coupon) promotion() {}
+
+// Money 金额, 序列化为 "12.34" 格式的字符串
+type Money int64
+
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(m)/100, 'f', 2, 64)), nil
+}
+
+// NullableTime 可以为空的时间
+// @schema string date-time
+type NullableTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+func (t NullableTime) MarshalJSON() ([]byte, error) {
+	if !t.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Time)
+}
+
+// CouponScope 优惠券适用范围. 同时实现了 MarshalJSON 和 MarshalText, 以 MarshalJSON 为准
+type CouponScope int
+
+func (s CouponScope) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]int{"scope": int(s)})
+}
+
+func (s CouponScope) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+type GoodsPromotionsRes struct {
+	Guid       string      `json:"guid"`
+	Promotions []Promotion `json:"promotions"`
+}
@@ -1,13 +1,37 @@
 package router
 
 import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"server/pkg/admin"
 	"server/pkg/controller"
 	"server/pkg/handler"
+	"server/pkg/middleware"
+	"server/pkg/service"
 	"server/pkg/shop"
+	"server/pkg/user"
 
 	"github.com/gin-gonic/gin"
 )
 
+type route struct {
+	Method  string
+	Path    string
+	Handler gin.HandlerFunc
+}
+
+const goodsPath = "/goods"
+
+var apiVersion = 3
+
+// routes 以数据形式声明的路由表
+var routes = []route{
+	{Method: http.MethodGet, Path: "/goods/:guid/stock", Handler: shop.GoodsStock},
+	{"PUT", "/goods/:guid/stock", shop.GoodsStockUpdate},
+}
+
 type CustomGroup struct {
 	*gin.RouterGroup
 }
@@ -42,5 +66,42 @@ func ServeHttp() *gin.Engine {
 	goodsController := controller.NewGoodsController()
 	g.DELETE("/controller/goods/:guid", goodsController.Delete)
 
+	g.POST("/goods/:guid/up", handler.Recover(handler.Auth(shop.GoodsUp)))
+
+	// generic adapter
+	goodsService := &service.GoodsService{}
+	g.GET("/goods/search", handler.Handle[service.GoodsSearchReq, service.GoodsSearchRes](goodsService.Search))
+	g.PUT("/goods", handler.Auth(handler.Handle(goodsService.Update)))
+
+	// route table
+	for _, rt := range routes {
+		g.Handle(rt.Method, rt.Path, rt.Handler)
+	}
+	for p, h := range map[string]gin.HandlerFunc{"/goods/hot": shop.GoodsHot} {
+		g.GET(p, h)
+	}
+	r.Any("/ping", shop.Ping)
+
+	// cross-package route group
+	user.RegisterRoutes(g.Group("/users", middleware.AuthRequired()))
+	g.POST("/session", user.Login)
+	// nested cross-package route group
+	admin.Setup(r, g.Group("/admin"))
+
+	// non-literal paths
+	v3 := r.Group(fmt.Sprintf("/api/v%d", apiVersion))
+	v3.GET(path.Join(goodsPath, "recommend"), shop.GoodsRecommend)
+	v3.GET(goodsPath+"/:guid/comments", shop.GoodsComments)
+	v3.PUT(goodsPath+"/:guid/price", shop.GoodsPriceUpdate)
+
+	v3.GET(goodsPath+"/export", shop.GoodsExport)
+	v3.POST(goodsPath+"/:guid/archive", shop.GoodsArchive)
+	v3.GET(goodsPath+"/:guid/page", shop.GoodsPage)
+	v3.GET(goodsPath+"/:guid/cover", shop.GoodsCover)
+	v3.GET(goodsPath+"/:guid/similar", shop.GoodsSimilar)
+	v3.GET(goodsPath+"/search", shop.GoodsSearch)
+	v3.GET(goodsPath+"/:guid/brief", shop.GoodsBrief)
+	v3.GET(goodsPath+"/:guid/promotions", shop.GoodsPromotions)
+
 	return r
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	bookstorev1 "gatewayserver/gen/bookstore/v1"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type bookServer struct {
+	bookstorev1.UnimplementedBookServiceServer
+}
+
+func main() {
+	ctx := context.Background()
+	mux := runtime.NewServeMux()
+
+	_ = bookstorev1.RegisterBookServiceHandlerServer(ctx, mux, &bookServer{})
+
+	// @tags Shelf
+	_ = bookstorev1.RegisterShelfServiceHandlerFromEndpoint(
+		ctx,
+		mux,
+		"localhost:9090",
+		[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+	)
+
+	_ = http.ListenAndServe(":8080", mux)
+}
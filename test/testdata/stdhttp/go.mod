@@ -0,0 +1,3 @@
+module stdhttp
+
+go 1.22
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"stdhttp/model"
+)
+
+type ItemHandler struct{}
+
+func NewItemHandler() *ItemHandler {
+	return &ItemHandler{}
+}
+
+// List 商品列表
+// @tags Item
+func (h *ItemHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	// Page number
+	_ = q.Get("page")
+	// Search keyword
+	_ = r.URL.Query().Get("keyword")
+
+	json.NewEncoder(w).Encode(model.ItemList{})
+}
+
+// Get 商品详情
+// @tags Item
+func (h *ItemHandler) Get(w http.ResponseWriter, r *http.Request) {
+	// Item ID
+	id := r.PathValue("id")
+	if id == "" {
+		// Item not found
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(model.Error{})
+		return
+	}
+
+	json.NewEncoder(w).Encode(&model.Item{})
+}
+
+// Create 创建商品
+// @tags Item
+func (h *ItemHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		// Invalid request body
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(model.Item{})
+}
+
+// Delete 删除商品
+// @tags Item
+func (h *ItemHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	_ = r.PathValue("id")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Upload 上传商品图片
+// @tags Item
+func (h *ItemHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	_ = r.PathValue("id")
+	// Image file
+	_, _, _ = r.FormFile("image")
+	// Image title
+	_ = r.FormValue("title")
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+)
+
+type FileHandler struct{}
+
+// ServeHTTP 下载文件
+func (h *FileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_ = r.PathValue("path")
+	// Download file name
+	_ = r.FormValue("name")
+}
+
+type ReportHandler struct{}
+
+// ServeHTTP 提交报表
+func (h *ReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+	form := r.PostForm
+	_ = form.Get("draft")
+	values := url.Values{}
+	_ = values.Get("format")
+	// Report period
+	_ = r.URL.Query().Get("period")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Health 健康检查
+func Health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// Debug 调试信息
+// @hidden-in-production
+func Debug(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// Logout 退出登录
+func Logout(w http.ResponseWriter, r *http.Request) {
+	// 当前会话
+	// @required
+	_, _ = r.Cookie("session_id")
+	http.SetCookie(w, &http.Cookie{Name: "session_id", MaxAge: -1})
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+
+	"stdhttp/handler"
+)
+
+const itemsPath = "/items"
+
+func main() {
+	mux := http.NewServeMux()
+	h := handler.NewItemHandler()
+
+	mux.HandleFunc("GET "+itemsPath, h.List)
+	mux.HandleFunc("GET /items/{id}", h.Get)
+	mux.HandleFunc(http.MethodPost+" "+itemsPath, h.Create)
+	mux.Handle("DELETE /items/{id}", http.HandlerFunc(h.Delete))
+	mux.HandleFunc("POST /items/{id}/images", h.Upload)
+	mux.Handle("GET /files/{path...}", &handler.FileHandler{})
+	mux.Handle("POST /reports", &handler.ReportHandler{})
+	mux.HandleFunc("/healthz", handler.Health)
+	mux.HandleFunc("GET /debug", handler.Debug)
+	mux.HandleFunc("DELETE /session", handler.Logout)
+
+	_ = http.ListenAndServe(":8080", mux)
+}
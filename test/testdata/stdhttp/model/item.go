@@ -0,0 +1,27 @@
+package model
+
+type Item struct {
+	// Item ID
+	ID int64 `json:"id"`
+	// Item name
+	// @required
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags"`
+}
+
+type CreateItemRequest struct {
+	// @required
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+type ItemList struct {
+	Items []*Item `json:"items"`
+	Total int     `json:"total"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
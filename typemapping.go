@@ -0,0 +1,159 @@
+package eapi
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gotomicro/eapi/spec"
+)
+
+// TypeMapping 将类型映射为指定的 schema. Type 为类型的完整名称, e.g. "github.com/shopspring/decimal.Decimal"
+type TypeMapping struct {
+	Type   string
+	Schema *TypeMappingSchema
+}
+
+// TypeMappingSchema 格式同插件配置中的 common.DataSchema, 另外支持 example. Type 为 "any" 时表示任意类型.
+// 与 common.DataSchema 不同, 未知的 Type 不会被当作类型参数, 而是作为无效的映射报告
+type TypeMappingSchema struct {
+	Type    string
+	Format  string
+	Example interface{}
+
+	Properties map[string]*TypeMappingSchema
+	// set when Type='array'
+	Item     *TypeMappingSchema
+	Optional bool
+}
+
+func (m *TypeMappingSchema) toSchema() (*spec.Schema, error) {
+	var schema *spec.Schema
+	switch m.Type {
+	case spec.TypeString, spec.TypeNumber, spec.TypeInteger, spec.TypeBoolean, "file":
+		schema = spec.NewSchema().WithType(m.Type)
+	case spec.TypeArray:
+		if m.Item == nil {
+			return nil, fmt.Errorf("'item' of array is not set")
+		}
+		item, err := m.Item.toSchema()
+		if err != nil {
+			return nil, err
+		}
+		schema = spec.NewArraySchema(item)
+	case spec.TypeObject:
+		schema = spec.NewObjectSchema()
+		for name, property := range m.Properties {
+			propertySchema, err := property.toSchema()
+			if err != nil {
+				return nil, fmt.Errorf("property '%s': %w", name, err)
+			}
+			schema.WithProperty(name, propertySchema)
+			if !property.Optional {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		sort.Strings(schema.Required)
+	case "any":
+		schema = anyJSONSchema()
+	default:
+		return nil, fmt.Errorf("invalid type '%s'", m.Type)
+	}
+	if m.Format != "" {
+		schema.Format = m.Format
+	}
+	schema.Example = m.Example
+	return schema, nil
+}
+
+func anyJSONSchema() *spec.Schema {
+	return spec.NewSchema().
+		WithType("object").
+		WithDescription("Any Json Type").
+		WithExtendedType(spec.NewAnyExtendedType())
+}
+
+// builtinTypeMappings 内置的常用类型映射. 可以通过配置文件中的 typeMappings 覆盖
+var builtinTypeMappings = map[string]*spec.Schema{
+	"time.Time":                spec.NewSchema().WithType("string").WithFormat("datetime"),
+	"encoding/json.RawMessage": anyJSONSchema(),
+	"json.RawMessage":          anyJSONSchema(),
+	"database/sql.NullTime":    spec.NewDateTimeSchema(),
+	"database/sql.NullString":  spec.NewStringSchema(),
+	"database/sql.NullInt64":   spec.NewInt64Schema(),
+	"database/sql.NullInt32":   spec.NewInt32Schema(),
+	"database/sql.NullInt":     spec.NewIntegerSchema(),
+	"database/sql.NullInt16":   spec.NewIntegerSchema(),
+	"database/sql.NullFloat64": spec.NewFloat64Schema(),
+	"database/sql.NullBool":    spec.NewBoolSchema(),
+	"database/sql.NullByte":    spec.NewStringSchema(),
+	// protobuf well-known types. 参考 protojson 的编码方式
+	"google.golang.org/protobuf/types/known/timestamppb.Timestamp": spec.NewDateTimeSchema(),
+	"google.golang.org/protobuf/types/known/durationpb.Duration":   spec.NewStringSchema(),
+	"google.golang.org/protobuf/types/known/emptypb.Empty":         spec.NewObjectSchema(),
+	"google.golang.org/protobuf/types/known/structpb.Struct":       anyJSONSchema(),
+	// gorm
+	"gorm.io/gorm.DeletedAt":               spec.NewDateTimeSchema(),
+	"gorm.io/datatypes.JSON":               anyJSONSchema(),
+	"gorm.io/datatypes.Date":               spec.NewDateTimeSchema(),
+	"gorm.io/datatypes.Time":               spec.NewStringSchema().WithFormat("time"),
+	"gorm.io/datatypes.UUID":               spec.NewUUIDSchema(),
+	"gorm.io/datatypes.JSONMap":            anyJSONSchema(),
+	"gorm.io/plugin/soft_delete.DeletedAt": spec.NewInt64Schema(),
+	// decimal
+	"github.com/shopspring/decimal.Decimal":     spec.NewStringSchema().WithFormat("decimal"),
+	"github.com/shopspring/decimal.NullDecimal": spec.NewStringSchema().WithFormat("decimal"),
+	// uuid
+	"github.com/google/uuid.UUID":        spec.NewUUIDSchema(),
+	"github.com/google/uuid.NullUUID":    spec.NewUUIDSchema(),
+	"github.com/gofrs/uuid.UUID":         spec.NewUUIDSchema(),
+	"github.com/gofrs/uuid.NullUUID":     spec.NewUUIDSchema(),
+	"github.com/gofrs/uuid/v5.UUID":      spec.NewUUIDSchema(),
+	"github.com/satori/go.uuid.UUID":     spec.NewUUIDSchema(),
+	"github.com/satori/go.uuid.NullUUID": spec.NewUUIDSchema(),
+	// guregu/null
+	"gopkg.in/guregu/null.v4.String":   spec.NewStringSchema(),
+	"gopkg.in/guregu/null.v4.Int":      spec.NewInt64Schema(),
+	"gopkg.in/guregu/null.v4.Float":    spec.NewFloat64Schema(),
+	"gopkg.in/guregu/null.v4.Bool":     spec.NewBoolSchema(),
+	"gopkg.in/guregu/null.v4.Time":     spec.NewDateTimeSchema(),
+	"github.com/guregu/null/v5.String": spec.NewStringSchema(),
+	"github.com/guregu/null/v5.Int":    spec.NewInt64Schema(),
+	"github.com/guregu/null/v5.Float":  spec.NewFloat64Schema(),
+	"github.com/guregu/null/v5.Bool":   spec.NewBoolSchema(),
+	"github.com/guregu/null/v5.Time":   spec.NewDateTimeSchema(),
+	// mongo
+	"go.mongodb.org/mongo-driver/bson/primitive.ObjectID":   spec.NewStringSchema().WithFormat("objectid"),
+	"go.mongodb.org/mongo-driver/bson/primitive.DateTime":   spec.NewDateTimeSchema(),
+	"go.mongodb.org/mongo-driver/bson/primitive.Decimal128": spec.NewStringSchema().WithFormat("decimal"),
+}
+
+// TypeMappings 添加自定义类型映射. 优先级高于内置的类型映射
+func (a *Analyzer) TypeMappings(mappings ...*TypeMapping) *Analyzer {
+	for _, mapping := range mappings {
+		typeName := strings.TrimPrefix(mapping.Type, "*")
+		if typeName == "" || mapping.Schema == nil {
+			fmt.Fprintf(os.Stderr, "invalid type mapping '%s': type or schema is not set\n", mapping.Type)
+			continue
+		}
+		schema, err := mapping.Schema.toSchema()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid type mapping '%s': %s\n", mapping.Type, err.Error())
+			continue
+		}
+		a.typeMappings[typeName] = schema
+	}
+	return a
+}
+
+// lookupTypeMapping 查找类型映射. typeName 为类型的完整名称
+func (a *Analyzer) lookupTypeMapping(typeName string) *spec.Schema {
+	if schema, ok := a.typeMappings[typeName]; ok {
+		return schema.Clone()
+	}
+	if schema, ok := builtinTypeMappings[typeName]; ok {
+		return schema.Clone()
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package eapi
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gotomicro/eapi/spec"
+	"github.com/gotomicro/eapi/tag"
+)
+
+// validationFormats 可以直接转换为 format 的校验规则
+var validationFormats = map[string]string{
+	"email":    "email",
+	"uuid":     "uuid",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"url":      "uri",
+	"uri":      "uri",
+	"http_url": "uri",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"hostname": "hostname",
+}
+
+// validationPatterns 可以转换为 pattern 的校验规则
+var validationPatterns = map[string]string{
+	"alpha":       "^[a-zA-Z]+$",
+	"alphanum":    "^[a-zA-Z0-9]+$",
+	"numeric":     "^[-+]?[0-9]+(?:\\.[0-9]+)?$",
+	"number":      "^[0-9]+$",
+	"hexadecimal": "^(0[xX])?[0-9a-fA-F]+$",
+	"lowercase":   "^[^A-Z]*$",
+	"uppercase":   "^[^a-z]*$",
+}
+
+// applyValidationTags 将字段 tag 中的 binding/validate 校验规则转换为 schema 的约束. e.g.
+//
+//	`binding:"min=1,max=64,email"` => minLength: 1, maxLength: 64, format: email
+//
+// $ref 指向的 schema 是共享的, 不会被修改
+func applyValidationTags(schema *spec.Schema, tags map[string]string) {
+	rules, elem := tag.ParseValidationRules(tags)
+	applyValidationRules(schema, rules)
+	if schema != nil && schema.Items != nil {
+		applyValidationRules(schema.Items, elem)
+	}
+}
+
+func applyValidationRules(schema *spec.Schema, rules []tag.ValidationRule) {
+	if schema == nil || schema.Ref != "" {
+		return
+	}
+	for _, rule := range rules {
+		switch rule.Name {
+		case "min", "gte":
+			setMinimum(schema, rule.Param, false)
+		case "gt":
+			setMinimum(schema, rule.Param, true)
+		case "max", "lte":
+			setMaximum(schema, rule.Param, false)
+		case "lt":
+			setMaximum(schema, rule.Param, true)
+		case "len":
+			setMinimum(schema, rule.Param, false)
+			setMaximum(schema, rule.Param, false)
+		case "oneof":
+			setEnum(schema, rule.Param)
+		case "startswith":
+			schema.Pattern = "^" + regexp.QuoteMeta(rule.Param)
+		case "endswith":
+			schema.Pattern = regexp.QuoteMeta(rule.Param) + "$"
+		case "contains":
+			schema.Pattern = regexp.QuoteMeta(rule.Param)
+		default:
+			if format, ok := validationFormats[rule.Name]; ok {
+				schema.Format = format
+			} else if pattern, ok := validationPatterns[rule.Name]; ok {
+				schema.Pattern = pattern
+			}
+		}
+	}
+}
+
+// setMinimum 根据 schema 类型设置 minimum/minLength/minItems/minProperties. exclusive 对应 gt
+func setMinimum(schema *spec.Schema, param string, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch schema.Type {
+	case spec.TypeInteger, spec.TypeNumber:
+		schema.WithMin(value).WithExclusiveMin(exclusive)
+	case spec.TypeString:
+		schema.MinLength = lengthOf(value, exclusive, 1)
+	case spec.TypeArray:
+		schema.MinItems = lengthOf(value, exclusive, 1)
+	case spec.TypeObject:
+		schema.MinProps = lengthOf(value, exclusive, 1)
+	}
+}
+
+// setMaximum 根据 schema 类型设置 maximum/maxLength/maxItems/maxProperties. exclusive 对应 lt
+func setMaximum(schema *spec.Schema, param string, exclusive bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch schema.Type {
+	case spec.TypeInteger, spec.TypeNumber:
+		schema.WithMax(value).WithExclusiveMax(exclusive)
+	case spec.TypeString:
+		n := lengthOf(value, exclusive, -1)
+		schema.MaxLength = &n
+	case spec.TypeArray:
+		n := lengthOf(value, exclusive, -1)
+		schema.MaxItems = &n
+	case spec.TypeObject:
+		n := lengthOf(value, exclusive, -1)
+		schema.MaxProps = &n
+	}
+}
+
+// lengthOf 将长度限制转换为整数. exclusive 时按照 delta 调整边界. e.g. gt=3 => minLength: 4
+func lengthOf(value float64, exclusive bool, delta int64) uint64 {
+	n := int64(value)
+	if exclusive {
+		n += delta
+	}
+	if n < 0 {
+		return 0
+	}
+	return uint64(n)
+}
+
+// setEnum 将 oneof 的参数转换为 enum. e.g. oneof=red green 'light blue'
+func setEnum(schema *spec.Schema, param string) {
+	var values []interface{}
+	for _, item := range splitOneOfParam(param) {
+		switch schema.Type {
+		case spec.TypeInteger:
+			if v, err := strconv.ParseInt(item, 10, 64); err == nil {
+				values = append(values, v)
+			}
+		case spec.TypeNumber:
+			if v, err := strconv.ParseFloat(item, 64); err == nil {
+				values = append(values, v)
+			}
+		default:
+			values = append(values, item)
+		}
+	}
+	if len(values) > 0 {
+		schema.Enum = values
+	}
+}
+
+var oneOfParamPattern = regexp.MustCompile(`'[^']*'|\S+`)
+
+func splitOneOfParam(param string) (res []string) {
+	for _, item := range oneOfParamPattern.FindAllString(param, -1) {
+		res = append(res, strings.Trim(item, "'"))
+	}
+	return
+}